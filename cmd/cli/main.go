@@ -2,11 +2,16 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"payment-broker/internal/app"
+	"payment-broker/internal/lib"
 	"payment-broker/internal/repository"
 	"payment-broker/internal/service"
+	"time"
 
+	"github.com/go-resty/resty/v2"
 	"github.com/joho/godotenv"
+	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 )
 
@@ -20,9 +25,55 @@ func main() {
 
 	db := app.InitDB(logger)
 
+	// Approving a payout enforces the same Redis-backed limits as the API, and
+	// reconciliation takes the same Redis lock.
+	cache := redis.NewClient(&redis.Options{
+		Addr:     os.Getenv("REDIS_ADDR"),
+		Password: os.Getenv("REDIS_PWD"),
+		DB:       0,
+	})
+	redisLib := lib.NewRedisLib(cache)
+
+	egress, webhookResty := app.InitEgress(logger)
+	resty := resty.New().SetTimeout(10 * time.Second)
+
 	tenantRepo := repository.NewTenantRepository(logger, db)
-	tenantService := service.NewTenantService(logger, nil, nil, tenantRepo)
-	cliService := service.NewCLIService(tenantService)
+	webhookEndpointRepo := repository.NewWebhookEndpointRepository(logger, db)
+	webhookEndpointService := service.NewWebhookEndpointService(logger, tenantRepo, webhookEndpointRepo)
+	webhookDeliveryRepo := repository.NewWebhookDeliveryRepository(logger, db)
+	webhookDeliveryService := service.NewWebhookDeliveryService(logger, webhookDeliveryRepo)
+	webhookBreakerRepo := repository.NewWebhookBreakerRepository(logger, db)
+	webhookBreakerService := service.NewWebhookBreakerService(logger, webhookBreakerRepo)
+	xenditService := service.NewXenditService(resty, webhookResty, logger, tenantRepo, webhookEndpointService, webhookDeliveryService,
+		webhookBreakerService)
+	webhookPingService := service.NewWebhookPingService(logger, xenditService)
+	splitRuleRepo := repository.NewSplitRuleRepository(logger, db)
+	payoutRepo := repository.NewPayoutRepository(logger, db)
+	payoutLimitRepo := repository.NewPayoutLimitRepository(logger, db)
+	payoutApprovalRepo := repository.NewPayoutApprovalRepository(logger, db)
+	beneficiaryRepo := repository.NewBeneficiaryRepository(logger, db)
+	feeRepo := repository.NewFeeRepository(logger, db)
+	usageRepo := repository.NewUsageRepository(logger, db)
+	invoiceRepo := repository.NewInvoiceRepository(logger, db)
+	reconciliationRepo := repository.NewReconciliationRepository(logger, db)
+	ledgerRepo := repository.NewLedgerRepository(logger, db)
+	tenantService := service.NewTenantService(logger, resty, redisLib, tenantRepo, xenditService, egress)
+	splitRuleService := service.NewSplitRuleService(logger, xenditService, tenantRepo, splitRuleRepo)
+	payoutService := service.NewPayoutService(logger, payoutRepo)
+	payoutLimitService := service.NewPayoutLimitService(logger, redisLib, tenantRepo, payoutLimitRepo)
+	payoutApprovalService := service.NewPayoutApprovalService(logger, resty, xenditService, payoutService,
+		payoutLimitService, tenantRepo, payoutApprovalRepo)
+	beneficiaryService := service.NewBeneficiaryService(logger, payoutLimitService, beneficiaryRepo)
+	feeService := service.NewFeeService(logger, tenantRepo, feeRepo)
+	usageService := service.NewUsageService(logger, usageRepo)
+	invoiceService := service.NewInvoiceService(logger, invoiceRepo)
+	ledgerService := service.NewLedgerService(logger, xenditService, tenantRepo, feeRepo, ledgerRepo)
+	reconciliationService := service.NewReconciliationService(logger, redisLib, xenditService, invoiceService, payoutService, feeService,
+		ledgerService, tenantRepo, invoiceRepo, payoutRepo, reconciliationRepo)
+	settlementService := service.NewSettlementService(tenantRepo, invoiceRepo, payoutRepo)
+	cliService := service.NewCLIService(tenantService, splitRuleService, payoutLimitService, payoutApprovalService,
+		beneficiaryService, feeService, usageService, reconciliationService, settlementService, webhookDeliveryService,
+		webhookPingService, egress)
 
 	cliService.MainMenu()
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"os"
 	"payment-broker/internal/app"
 
@@ -31,6 +32,10 @@ func main() {
 
 	db := app.InitDB(logger)
 	router := app.InitApp(db, logger, cache)
+	router.Service.Reconciliation.StartScheduler(context.Background())
+	router.Service.WebhookWorker.StartWorker(context.Background())
+	router.Service.EventLog.StartRetention(context.Background())
+	router.Service.EventPublisher.StartRelay(context.Background())
 
 	fapp := fiber.New()
 	fapp.Use(recover.New())
@@ -40,8 +45,8 @@ func main() {
 
 	fapp.Use(cors.New(cors.Config{
 		AllowOrigins: "*",
-		AllowHeaders: "Content-Type, X-API-Key",
-		AllowMethods: "POST",
+		AllowHeaders: "Content-Type, X-API-Key, X-Admin-Key, X-Requested-By, Last-Event-ID",
+		AllowMethods: "GET, POST, PUT, PATCH, DELETE",
 	}))
 
 	app.InitRouter(fapp, router, cache)
@@ -15,17 +15,66 @@ import (
 
 type App struct {
 	Repository struct {
-		Tenant repository.TenantRepository
+		Tenant         repository.TenantRepository
+		SplitRule      repository.SplitRuleRepository
+		Subscription   repository.SubscriptionRepository
+		Payout         repository.PayoutRepository
+		PayoutLimit    repository.PayoutLimitRepository
+		PayoutApproval repository.PayoutApprovalRepository
+		Beneficiary    repository.BeneficiaryRepository
+		Fee            repository.FeeRepository
+		Usage          repository.UsageRepository
+		Invoice        repository.InvoiceRepository
+		Reconciliation repository.ReconciliationRepository
+		Ledger         repository.LedgerRepository
+
+		WebhookEndpoint repository.WebhookEndpointRepository
+		WebhookDelivery repository.WebhookDeliveryRepository
+		WebhookBreaker  repository.WebhookBreakerRepository
+		Event           repository.EventRepository
 	}
 
 	Service struct {
-		Tenant service.TenantService
-		Xendit service.XenditService
+		Tenant         service.TenantService
+		Xendit         service.XenditService
+		SplitRule      service.SplitRuleService
+		Subscription   service.SubscriptionService
+		Payout         service.PayoutService
+		PayoutLimit    service.PayoutLimitService
+		PayoutApproval service.PayoutApprovalService
+		Beneficiary    service.BeneficiaryService
+		Fee            service.FeeService
+		Usage          service.UsageService
+		Invoice        service.InvoiceService
+		Reconciliation service.ReconciliationService
+		Ledger         service.LedgerService
+
+		WebhookEndpoint service.WebhookEndpointService
+		WebhookDelivery service.WebhookDeliveryService
+		WebhookPing     service.WebhookPingService
+		WebhookBreaker  service.WebhookBreakerService
+		WebhookWorker   service.WebhookWorkerService
+		EventLog        service.EventLogService
+		EventPublisher  service.EventPublisherService
 	}
 
 	Controller struct {
-		Xendit  controller.XenditController
-		Webhook controller.WebhookController
+		Xendit         controller.XenditController
+		Webhook        controller.WebhookController
+		SplitRule      controller.SplitRuleController
+		Subscription   controller.SubscriptionController
+		Payout         controller.PayoutController
+		PayoutLimit    controller.PayoutLimitController
+		PayoutApproval controller.PayoutApprovalController
+		Beneficiary    controller.BeneficiaryController
+		Fee            controller.FeeController
+		Reconciliation controller.ReconciliationController
+		Ledger         controller.LedgerController
+		Tenant         controller.TenantController
+
+		WebhookEndpoint controller.WebhookEndpointController
+		WebhookDelivery controller.WebhookDeliveryController
+		Event           controller.EventController
 	}
 }
 
@@ -33,13 +82,66 @@ func InitApp(db *gorm.DB, logger *zap.Logger, redis *redis.Client) *App {
 	app := &App{}
 
 	redisLib := lib.NewRedisLib(redis)
+	egress, webhookResty := InitEgress(logger)
 	resty := resty.New().SetTimeout(10 * time.Second)
 
 	app.Repository.Tenant = repository.NewTenantRepository(logger, db)
-	app.Service.Tenant = service.NewTenantService(logger, resty, redisLib, app.Repository.Tenant)
-	app.Service.Xendit = service.NewXenditService(resty, logger)
-	app.Controller.Xendit = controller.NewXenditController(logger, app.Service.Xendit)
-	app.Controller.Webhook = controller.NewWebhookController(logger, app.Service.Xendit)
+	app.Repository.SplitRule = repository.NewSplitRuleRepository(logger, db)
+	app.Repository.Subscription = repository.NewSubscriptionRepository(logger, db)
+	app.Repository.Payout = repository.NewPayoutRepository(logger, db)
+	app.Repository.PayoutLimit = repository.NewPayoutLimitRepository(logger, db)
+	app.Repository.PayoutApproval = repository.NewPayoutApprovalRepository(logger, db)
+	app.Repository.Beneficiary = repository.NewBeneficiaryRepository(logger, db)
+	app.Repository.Fee = repository.NewFeeRepository(logger, db)
+	app.Repository.Usage = repository.NewUsageRepository(logger, db)
+	app.Repository.Invoice = repository.NewInvoiceRepository(logger, db)
+	app.Repository.Reconciliation = repository.NewReconciliationRepository(logger, db)
+	app.Repository.Ledger = repository.NewLedgerRepository(logger, db)
+	app.Repository.WebhookEndpoint = repository.NewWebhookEndpointRepository(logger, db)
+	app.Service.WebhookEndpoint = service.NewWebhookEndpointService(logger, app.Repository.Tenant, app.Repository.WebhookEndpoint)
+	app.Repository.WebhookDelivery = repository.NewWebhookDeliveryRepository(logger, db)
+	app.Service.WebhookDelivery = service.NewWebhookDeliveryService(logger, app.Repository.WebhookDelivery)
+	app.Repository.WebhookBreaker = repository.NewWebhookBreakerRepository(logger, db)
+	app.Service.WebhookBreaker = service.NewWebhookBreakerService(logger, app.Repository.WebhookBreaker)
+	app.Service.Xendit = service.NewXenditService(resty, webhookResty, logger, app.Repository.Tenant, app.Service.WebhookEndpoint,
+		app.Service.WebhookDelivery, app.Service.WebhookBreaker)
+	app.Service.WebhookWorker = service.NewWebhookWorkerService(logger, app.Service.Xendit, app.Service.WebhookBreaker)
+	app.Repository.Event = repository.NewEventRepository(logger, db)
+	app.Service.EventLog = service.NewEventLogService(logger, app.Repository.Event)
+	app.Service.EventPublisher = service.NewEventPublisherService(logger, InitPublisher(logger, redis), app.Repository.Event)
+	app.Service.Tenant = service.NewTenantService(logger, resty, redisLib, app.Repository.Tenant, app.Service.Xendit, egress)
+	app.Service.SplitRule = service.NewSplitRuleService(logger, app.Service.Xendit, app.Repository.Tenant, app.Repository.SplitRule)
+	app.Service.Subscription = service.NewSubscriptionService(logger, app.Repository.Subscription)
+	app.Service.Payout = service.NewPayoutService(logger, app.Repository.Payout)
+	app.Service.PayoutLimit = service.NewPayoutLimitService(logger, redisLib, app.Repository.Tenant, app.Repository.PayoutLimit)
+	app.Service.PayoutApproval = service.NewPayoutApprovalService(logger, resty, app.Service.Xendit, app.Service.Payout,
+		app.Service.PayoutLimit, app.Repository.Tenant, app.Repository.PayoutApproval)
+	app.Service.Beneficiary = service.NewBeneficiaryService(logger, app.Service.PayoutLimit, app.Repository.Beneficiary)
+	app.Service.Fee = service.NewFeeService(logger, app.Repository.Tenant, app.Repository.Fee)
+	app.Service.Usage = service.NewUsageService(logger, app.Repository.Usage)
+	app.Service.Invoice = service.NewInvoiceService(logger, app.Repository.Invoice)
+	app.Service.WebhookPing = service.NewWebhookPingService(logger, app.Service.Xendit)
+	app.Service.Ledger = service.NewLedgerService(logger, app.Service.Xendit, app.Repository.Tenant, app.Repository.Fee, app.Repository.Ledger)
+	app.Service.Reconciliation = service.NewReconciliationService(logger, redisLib, app.Service.Xendit, app.Service.Invoice, app.Service.Payout,
+		app.Service.Fee, app.Service.Ledger, app.Repository.Tenant, app.Repository.Invoice, app.Repository.Payout, app.Repository.Reconciliation)
+	app.Controller.Xendit = controller.NewXenditController(logger, app.Service.Xendit, app.Service.Tenant, app.Service.SplitRule, app.Service.Invoice,
+		app.Service.Subscription, app.Service.Payout, app.Service.PayoutLimit, app.Service.PayoutApproval, app.Service.Beneficiary)
+	app.Controller.SplitRule = controller.NewSplitRuleController(logger, app.Service.SplitRule)
+	app.Controller.Subscription = controller.NewSubscriptionController(logger, app.Service.Xendit, app.Service.Subscription)
+	app.Controller.Payout = controller.NewPayoutController(logger, app.Service.Xendit, app.Service.Payout)
+	app.Controller.PayoutLimit = controller.NewPayoutLimitController(logger, app.Service.PayoutLimit)
+	app.Controller.PayoutApproval = controller.NewPayoutApprovalController(logger, app.Service.PayoutApproval)
+	app.Controller.Beneficiary = controller.NewBeneficiaryController(logger, app.Service.Beneficiary)
+	app.Controller.Fee = controller.NewFeeController(logger, app.Service.Fee)
+	app.Controller.Reconciliation = controller.NewReconciliationController(logger, app.Service.Reconciliation)
+	app.Controller.Ledger = controller.NewLedgerController(logger, app.Service.Ledger)
+	app.Controller.Tenant = controller.NewTenantController(logger, app.Service.Tenant)
+	app.Controller.WebhookEndpoint = controller.NewWebhookEndpointController(logger, app.Service.WebhookEndpoint, egress)
+	app.Controller.WebhookDelivery = controller.NewWebhookDeliveryController(logger, app.Service.WebhookDelivery, app.Service.WebhookPing,
+		app.Service.WebhookBreaker)
+	app.Controller.Webhook = controller.NewWebhookController(logger, app.Service.Xendit, app.Service.Tenant, app.Service.Subscription, app.Service.Payout,
+		app.Service.Invoice, app.Service.Fee, app.Service.Ledger, app.Service.EventLog)
+	app.Controller.Event = controller.NewEventController(logger, app.Service.EventLog)
 
 	return app
 }
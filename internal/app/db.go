@@ -16,7 +16,30 @@ func InitDB(logger *zap.Logger) *gorm.DB {
 		logger.Fatal("failed to connect DB", zap.Error(err))
 	}
 
-	if err := db.AutoMigrate(&model.Tenant{}); err != nil {
+	if err := db.AutoMigrate(
+		&model.Tenant{},
+		&model.SplitRule{},
+		&model.SubscriptionPlan{},
+		&model.SubscriptionCycle{},
+		&model.Payout{},
+		&model.PayoutLimit{},
+		&model.PayoutApproval{},
+		&model.PayoutApprovalEvent{},
+		&model.Beneficiary{},
+		&model.FeeRule{},
+		&model.PlatformFee{},
+		&model.UsageRecord{},
+		&model.Invoice{},
+		&model.ReconciliationRun{},
+		&model.ReconciliationMismatch{},
+		&model.LedgerEntry{},
+		&model.LedgerLine{},
+		&model.WebhookEndpoint{},
+		&model.WebhookDelivery{},
+		&model.WebhookBreaker{},
+		&model.QueuedWebhook{},
+		&model.TenantEvent{},
+	); err != nil {
 		logger.Fatal("auto migrate failed", zap.Error(err))
 	}
 
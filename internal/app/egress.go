@@ -0,0 +1,26 @@
+package app
+
+import (
+	"payment-broker/internal/helper"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"go.uber.org/zap"
+)
+
+// InitEgress loads the egress policy and builds the client tenant webhooks are
+// sent with. Redirects are not followed, so an endpoint can't bounce the
+// broker from https:// to http://.
+func InitEgress(logger *zap.Logger) (*helper.EgressPolicy, *resty.Client) {
+	egress, err := helper.LoadEgressPolicy()
+	if err != nil {
+		logger.Fatal("failed to load egress policy", zap.Error(err))
+	}
+
+	client := resty.New().
+		SetTimeout(10 * time.Second).
+		SetTransport(egress.Transport()).
+		SetRedirectPolicy(resty.NoRedirectPolicy())
+
+	return egress, client
+}
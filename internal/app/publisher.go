@@ -0,0 +1,41 @@
+package app
+
+import (
+	"os"
+	"payment-broker/internal/lib"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultEventStream       = "payment-broker:events"
+	defaultEventStreamMaxLen = 100000
+)
+
+// InitPublisher builds the message bus publisher chosen by EVENT_PUBLISHER.
+// "redis" appends to the Redis stream EVENT_STREAM, trimmed to about
+// EVENT_STREAM_MAXLEN entries. Events are not published when it is empty.
+func InitPublisher(logger *zap.Logger, redis *redis.Client) lib.Publisher {
+	switch kind := os.Getenv("EVENT_PUBLISHER"); kind {
+	case "":
+		return lib.NewNoopPublisher()
+	case "redis":
+		stream := os.Getenv("EVENT_STREAM")
+		if stream == "" {
+			stream = defaultEventStream
+		}
+
+		maxLen, err := strconv.ParseInt(os.Getenv("EVENT_STREAM_MAXLEN"), 10, 64)
+		if err != nil || maxLen < 0 {
+			maxLen = defaultEventStreamMaxLen
+		}
+
+		logger.Info("Publishing events to Redis stream", zap.String("stream", stream), zap.Int64("max_len", maxLen))
+		return lib.NewRedisStreamPublisher(redis, stream, maxLen)
+	default:
+		logger.Fatal("unknown EVENT_PUBLISHER", zap.String("publisher", kind))
+		return nil
+	}
+}
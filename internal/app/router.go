@@ -15,5 +15,11 @@ func InitRouter(f *fiber.App, app *App, redis *redis.Client) {
 	api := f.Group("/v1")
 	api.Use(middleware.LimiterMiddleware(limiter, redis_rate.PerSecond(3)))
 
-	router.NewXenditRouter(api, app.Service.Tenant, app.Controller.Xendit, app.Controller.Webhook)
+	router.NewXenditRouter(api, app.Service.Tenant, app.Service.Usage, app.Controller.Xendit, app.Controller.Webhook,
+		app.Controller.Subscription, app.Controller.Payout, app.Controller.PayoutApproval, app.Controller.Beneficiary, app.Controller.WebhookEndpoint,
+		app.Controller.WebhookDelivery)
+	router.NewAdminRouter(api, app.Controller.SplitRule, app.Controller.PayoutLimit, app.Controller.PayoutApproval,
+		app.Controller.Beneficiary, app.Controller.Fee, app.Controller.Reconciliation, app.Controller.Ledger, app.Controller.Tenant,
+		app.Controller.WebhookDelivery)
+	router.NewEventRouter(api, app.Service.Tenant, app.Service.Usage, app.Controller.Event)
 }
@@ -0,0 +1,190 @@
+package controller
+
+import (
+	model "payment-broker/internal/model/db"
+	"payment-broker/internal/model/dto"
+	"payment-broker/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type BeneficiaryController interface {
+	RegisterBeneficiary(c *fiber.Ctx) error
+	GetTenantBeneficiaries(c *fiber.Ctx) error
+	DeleteBeneficiary(c *fiber.Ctx) error
+	ListBeneficiaries(c *fiber.Ctx) error
+	VerifyBeneficiary(c *fiber.Ctx) error
+	RejectBeneficiary(c *fiber.Ctx) error
+}
+
+type beneficiaryController struct {
+	logger             *zap.Logger
+	beneficiaryService service.BeneficiaryService
+}
+
+func NewBeneficiaryController(logger *zap.Logger, beneficiaryService service.BeneficiaryService) BeneficiaryController {
+	return &beneficiaryController{
+		logger:             logger,
+		beneficiaryService: beneficiaryService,
+	}
+}
+
+// RegisterBeneficiary godoc
+// @Summary      Register Beneficiary
+// @Description  Register a payout destination, it stays PENDING_VERIFICATION until an operator verifies it
+// @Tags         action
+// @Accept       json
+// @Produce      json
+// @Param        X-Api-Key  header    string                  true  "API Key"
+// @Param        body       body      dto.BeneficiaryRequest  true  "Beneficiary payload"
+// @Success      201        {object}  map[string]interface{}  "Beneficiary registered"
+// @Failure      400        {object}  map[string]interface{}  "Invalid request body"
+// @Failure      422        {object}  map[string]interface{}  "Validation failed"
+// @Router       /xendit/action/beneficiaries [post]
+func (t *beneficiaryController) RegisterBeneficiary(c *fiber.Ctx) error {
+	tenantID := c.Locals("X-Tenant-ID").(string)
+
+	var body dto.BeneficiaryRequest
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if errs := body.Validate(); len(errs) > 0 {
+		return sendValidationErrors(c, errs)
+	}
+
+	beneficiary, err := t.beneficiaryService.Register(tenantID, &body)
+	if err != nil {
+		t.logger.Error("beneficiaryService.Register", zap.String("tenant_id", tenantID), zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to register beneficiary",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(beneficiary)
+}
+
+// GetTenantBeneficiaries godoc
+// @Summary      List Beneficiaries
+// @Description  List the tenant's registered payout destinations
+// @Tags         action
+// @Produce      json
+// @Param        X-Api-Key  header    string                  true  "API Key"
+// @Success      200        {array}   map[string]interface{}  "Beneficiaries"
+// @Failure      500        {object}  map[string]interface{}  "Failed to get beneficiaries"
+// @Router       /xendit/action/beneficiaries [get]
+func (t *beneficiaryController) GetTenantBeneficiaries(c *fiber.Ctx) error {
+	tenantID := c.Locals("X-Tenant-ID").(string)
+
+	beneficiaries, err := t.beneficiaryService.GetTenantBeneficiaries(tenantID)
+	if err != nil {
+		t.logger.Error("beneficiaryService.GetTenantBeneficiaries", zap.String("tenant_id", tenantID), zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get beneficiaries",
+		})
+	}
+
+	return c.JSON(beneficiaries)
+}
+
+// DeleteBeneficiary godoc
+// @Summary      Delete Beneficiary
+// @Description  Remove a payout destination from the tenant's registry
+// @Tags         action
+// @Produce      json
+// @Param        X-Api-Key  header    string                  true  "API Key"
+// @Param        id         path      int                     true  "Beneficiary ID"
+// @Success      200        {object}  map[string]interface{}  "Beneficiary deleted"
+// @Failure      404        {object}  map[string]interface{}  "Beneficiary not found"
+// @Router       /xendit/action/beneficiaries/{id} [delete]
+func (t *beneficiaryController) DeleteBeneficiary(c *fiber.Ctx) error {
+	tenantID := c.Locals("X-Tenant-ID").(string)
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid beneficiary ID",
+		})
+	}
+
+	if err := t.beneficiaryService.DeleteTenantBeneficiary(tenantID, uint(id)); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Beneficiary not found",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Beneficiary deleted successfully",
+	})
+}
+
+// ListBeneficiaries godoc
+// @Summary      List Beneficiaries
+// @Description  List beneficiaries across tenants, pending ones by default
+// @Tags         admin
+// @Produce      json
+// @Param        X-Admin-Key  header    string                  true   "Admin Key"
+// @Param        status       query     string                  false  "PENDING_VERIFICATION, VERIFIED or REJECTED"
+// @Success      200          {array}   map[string]interface{}  "Beneficiaries"
+// @Failure      500          {object}  map[string]interface{}  "Failed to get beneficiaries"
+// @Router       /admin/beneficiaries [get]
+func (t *beneficiaryController) ListBeneficiaries(c *fiber.Ctx) error {
+	beneficiaries, err := t.beneficiaryService.GetBeneficiaries(c.Query("status", model.BeneficiaryPending))
+	if err != nil {
+		t.logger.Error("beneficiaryService.GetBeneficiaries", zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get beneficiaries",
+		})
+	}
+
+	return c.JSON(beneficiaries)
+}
+
+// VerifyBeneficiary godoc
+// @Summary      Verify Beneficiary
+// @Description  Mark a pending beneficiary as verified so payouts to it are sent directly
+// @Tags         admin
+// @Produce      json
+// @Param        X-Admin-Key   header    string                  true  "Personal admin key from ADMIN_API_KEYS"
+// @Param        id            path      int                     true  "Beneficiary ID"
+// @Success      200           {object}  map[string]interface{}  "Beneficiary verified"
+// @Failure      400           {object}  map[string]interface{}  "Beneficiary can't be verified"
+// @Router       /admin/beneficiaries/{id}/verify [post]
+func (t *beneficiaryController) VerifyBeneficiary(c *fiber.Ctx) error {
+	return t.review(c, t.beneficiaryService.Verify)
+}
+
+// RejectBeneficiary godoc
+// @Summary      Reject Beneficiary
+// @Description  Reject a pending beneficiary
+// @Tags         admin
+// @Produce      json
+// @Param        X-Admin-Key   header    string                  true  "Personal admin key from ADMIN_API_KEYS"
+// @Param        id            path      int                     true  "Beneficiary ID"
+// @Success      200           {object}  map[string]interface{}  "Beneficiary rejected"
+// @Failure      400           {object}  map[string]interface{}  "Beneficiary can't be rejected"
+// @Router       /admin/beneficiaries/{id}/reject [post]
+func (t *beneficiaryController) RejectBeneficiary(c *fiber.Ctx) error {
+	return t.review(c, t.beneficiaryService.Reject)
+}
+
+func (t *beneficiaryController) review(c *fiber.Ctx, decide func(id uint, reviewer string) (*model.Beneficiary, error)) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid beneficiary ID",
+		})
+	}
+
+	beneficiary, err := decide(uint(id), adminUser(c))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(beneficiary)
+}
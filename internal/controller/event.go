@@ -0,0 +1,247 @@
+package controller
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"payment-broker/internal/model/dto"
+	"payment-broker/internal/service"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+const (
+	// eventStreamBatch is how many logged events are read per query while a
+	// stream catches up.
+	eventStreamBatch = 100
+
+	// eventStreamPoll bounds how long a stream waits before checking the log
+	// for events recorded by another instance.
+	eventStreamPoll = 2 * time.Second
+
+	// eventStreamHeartbeat keeps idle connections open through proxies.
+	eventStreamHeartbeat = 15 * time.Second
+)
+
+type EventController interface {
+	ListEvents(c *fiber.Ctx) error
+	AcknowledgeEvents(c *fiber.Ctx) error
+	StreamEvents(c *fiber.Ctx) error
+}
+
+type eventController struct {
+	logger          *zap.Logger
+	eventLogService service.EventLogService
+}
+
+func NewEventController(logger *zap.Logger, eventLogService service.EventLogService) EventController {
+	return &eventController{
+		logger:          logger,
+		eventLogService: eventLogService,
+	}
+}
+
+// ListEvents godoc
+// @Summary      List Events
+// @Description  The calling tenant's logged events after a cursor, oldest first, each with its cursor and the same envelope delivered to its webhooks. Pass the last cursor received as after to fetch the next page, for example from a cron that catches up on missed webhooks. Events are listed once they are EVENT_SETTLE_DELAY old (3s by default), so a cursor never moves past one still being recorded, and are kept for EVENT_RETENTION.
+// @Tags         events
+// @Produce      json
+// @Param        X-Api-Key  header    string                  true   "API Key"
+// @Param        after      query     int                     false  "Only events after this cursor, 0 by default"
+// @Param        limit      query     int                     false  "Number of events, 50 by default"
+// @Param        pending    query     bool                    false  "Leave out acknowledged events"
+// @Success      200        {array}   map[string]interface{}  "Events"
+// @Failure      400        {object}  map[string]interface{}  "Invalid query parameter"
+// @Failure      500        {object}  map[string]interface{}  "Failed to get events"
+// @Router       /events [get]
+func (t *eventController) ListEvents(c *fiber.Ctx) error {
+	tenantID := c.Locals("X-Tenant-ID").(string)
+
+	filter := dto.EventFilter{
+		Limit:   c.QueryInt("limit", 50),
+		Pending: c.QueryBool("pending"),
+	}
+	if filter.Limit < 1 || filter.Limit > 200 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "limit must be between 1 and 200",
+		})
+	}
+
+	after := c.QueryInt("after", 0)
+	if after < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "after must not be negative",
+		})
+	}
+	filter.After = uint(after)
+
+	events, err := t.eventLogService.GetTenantEvents(tenantID, filter)
+	if err != nil {
+		t.logger.Error("eventLogService.GetTenantEvents", zap.String("tenant_id", tenantID), zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get events",
+		})
+	}
+
+	return c.JSON(events)
+}
+
+// AcknowledgeEvents godoc
+// @Summary      Acknowledge Events
+// @Description  Mark the calling tenant's events at the given cursors as consumed, so they are left out when listing with pending=true. Acknowledging an event again keeps its first acknowledgement time.
+// @Tags         events
+// @Accept       json
+// @Produce      json
+// @Param        X-Api-Key  header    string                  true  "API Key"
+// @Param        body       body      map[string]interface{}  true  "Cursors to acknowledge, e.g. {\"cursors\": [41, 42]}"
+// @Success      200        {object}  map[string]interface{}  "Number of events newly acknowledged"
+// @Failure      400        {object}  map[string]interface{}  "Invalid request body"
+// @Failure      422        {object}  map[string]interface{}  "Validation errors"
+// @Failure      500        {object}  map[string]interface{}  "Failed to acknowledge events"
+// @Router       /events/ack [post]
+func (t *eventController) AcknowledgeEvents(c *fiber.Ctx) error {
+	tenantID := c.Locals("X-Tenant-ID").(string)
+
+	var body dto.EventAckRequest
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if errs := body.Validate(); len(errs) > 0 {
+		return sendValidationErrors(c, errs)
+	}
+
+	acknowledged, err := t.eventLogService.Acknowledge(tenantID, body.Cursors)
+	if err != nil {
+		t.logger.Error("eventLogService.Acknowledge", zap.String("tenant_id", tenantID), zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to acknowledge events",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"acknowledged": acknowledged,
+	})
+}
+
+// StreamEvents godoc
+// @Summary      Stream Events
+// @Description  Server-sent events carrying the same event envelopes that are delivered to the tenant's webhooks. Each message's id is its cursor in the tenant's event log and its event is the event type. Reconnect with the Last-Event-ID header, or the last_event_id query parameter, to resume after the last event received. Without either, the stream starts with the next event. Events are sent once they are EVENT_SETTLE_DELAY old (3s by default), so resuming never skips one still being recorded. A comment line is sent every 15 seconds while idle.
+// @Tags         events
+// @Produce      text/event-stream
+// @Param        X-Api-Key      header    string                  true   "API Key"
+// @Param        Last-Event-ID  header    int                     false  "Resume after this event"
+// @Param        last_event_id  query     int                     false  "Resume after this event, for clients that cannot set headers"
+// @Success      200            {string}  string                  "Event stream"
+// @Failure      400            {object}  map[string]interface{}  "Invalid Last-Event-ID"
+// @Failure      500            {object}  map[string]interface{}  "Failed to open event stream"
+// @Router       /events/stream [get]
+func (t *eventController) StreamEvents(c *fiber.Ctx) error {
+	tenantID := c.Locals("X-Tenant-ID").(string)
+
+	lastEventID := c.Get("Last-Event-ID", c.Query("last_event_id"))
+
+	var cursor uint
+	if lastEventID != "" {
+		id, err := strconv.ParseUint(lastEventID, 10, 64)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid Last-Event-ID",
+			})
+		}
+		cursor = uint(id)
+	} else {
+		latest, err := t.eventLogService.Latest(tenantID)
+		if err != nil {
+			t.logger.Error("eventLogService.Latest", zap.String("tenant_id", tenantID), zap.Error(err))
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to open event stream",
+			})
+		}
+		cursor = latest
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		notify, unsubscribe := t.eventLogService.Subscribe(tenantID)
+		defer unsubscribe()
+
+		// Announce the retry delay and flush the headers right away.
+		fmt.Fprintf(w, "retry: %d\n\n", eventStreamPoll.Milliseconds())
+		if err := w.Flush(); err != nil {
+			return
+		}
+
+		heartbeat := time.NewTicker(eventStreamHeartbeat)
+		defer heartbeat.Stop()
+		poll := time.NewTicker(eventStreamPoll)
+		defer poll.Stop()
+
+		for {
+			next, err := t.writeEvents(w, tenantID, cursor)
+			if err != nil {
+				return
+			}
+			cursor = next
+
+			select {
+			case <-notify:
+			case <-poll.C:
+			case <-heartbeat.C:
+				fmt.Fprint(w, ": ping\n\n")
+				if err := w.Flush(); err != nil {
+					return
+				}
+			}
+		}
+	})
+
+	return nil
+}
+
+// writeEvents sends every event logged after cursor and returns the cursor of
+// the last one sent. An error means the client has gone away.
+func (t *eventController) writeEvents(w *bufio.Writer, tenantID string, cursor uint) (uint, error) {
+	for {
+		events, err := t.eventLogService.GetTenantEvents(tenantID, dto.EventFilter{After: cursor, Limit: eventStreamBatch})
+		if err != nil {
+			// Keep the stream open and try again on the next wake-up.
+			t.logger.Error("eventLogService.GetTenantEvents", zap.String("tenant_id", tenantID), zap.Error(err))
+			return cursor, nil
+		}
+
+		for _, event := range events {
+			if err := writeEvent(w, event); err != nil {
+				t.logger.Error("Failed to encode event", zap.Uint("cursor", event.Cursor), zap.Error(err))
+			}
+			cursor = event.Cursor
+		}
+		if len(events) > 0 {
+			if err := w.Flush(); err != nil {
+				return cursor, err
+			}
+		}
+
+		if len(events) < eventStreamBatch {
+			return cursor, nil
+		}
+	}
+}
+
+func writeEvent(w *bufio.Writer, event dto.LoggedEvent) error {
+	data, err := json.Marshal(event.Event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Cursor, event.Type, data)
+	return err
+}
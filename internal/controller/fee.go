@@ -0,0 +1,124 @@
+package controller
+
+import (
+	"payment-broker/internal/model/dto"
+	"payment-broker/internal/service"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type FeeController interface {
+	GetFeeSchedule(c *fiber.Ctx) error
+	SetFeeSchedule(c *fiber.Ctx) error
+	GetFeeReport(c *fiber.Ctx) error
+}
+
+type feeController struct {
+	logger     *zap.Logger
+	feeService service.FeeService
+}
+
+func NewFeeController(logger *zap.Logger, feeService service.FeeService) FeeController {
+	return &feeController{
+		logger:     logger,
+		feeService: feeService,
+	}
+}
+
+// GetFeeSchedule godoc
+// @Summary      Get Fee Schedule
+// @Description  Get a tenant's platform fee rules
+// @Tags         admin
+// @Produce      json
+// @Param        X-Admin-Key  header    string                  true  "Admin Key"
+// @Param        id           path      int                     true  "Tenant ID"
+// @Success      200          {array}   map[string]interface{}  "Fee rules"
+// @Failure      400          {object}  map[string]interface{}  "Invalid tenant ID"
+// @Failure      500          {object}  map[string]interface{}  "Failed to get fee schedule"
+// @Router       /admin/tenants/{id}/fee-schedule [get]
+func (t *feeController) GetFeeSchedule(c *fiber.Ctx) error {
+	tenantID, err := c.ParamsInt("id")
+	if err != nil || tenantID <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid tenant ID",
+		})
+	}
+
+	rules, err := t.feeService.GetSchedule(uint(tenantID))
+	if err != nil {
+		t.logger.Error("feeService.GetSchedule", zap.Int("tenant_id", tenantID), zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get fee schedule",
+		})
+	}
+
+	return c.JSON(rules)
+}
+
+// SetFeeSchedule godoc
+// @Summary      Set Fee Schedule
+// @Description  Replace a tenant's platform fee rules. Each rule is PERCENTAGE (percentage plus flat_amount), FLAT or TIERED, and applies to a payment channel or method, or to everything else when payment_method is empty. A rule with a currency only applies to invoices in that currency and wins over one without.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        X-Admin-Key  header    string                  true  "Admin Key"
+// @Param        id           path      int                     true  "Tenant ID"
+// @Param        body         body      dto.FeeScheduleRequest  true  "Fee schedule"
+// @Success      200          {array}   map[string]interface{}  "Fee schedule saved successfully"
+// @Failure      400          {object}  map[string]interface{}  "Invalid request body"
+// @Router       /admin/tenants/{id}/fee-schedule [put]
+func (t *feeController) SetFeeSchedule(c *fiber.Ctx) error {
+	tenantID, err := c.ParamsInt("id")
+	if err != nil || tenantID <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid tenant ID",
+		})
+	}
+
+	var body dto.FeeScheduleRequest
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	rules, err := t.feeService.SetSchedule(uint(tenantID), body)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(rules)
+}
+
+// GetFeeReport godoc
+// @Summary      Get Fee Report
+// @Description  Platform fees from a tenant's invoices paid in a month, with totals per currency
+// @Tags         admin
+// @Produce      json
+// @Param        X-Admin-Key  header    string                  true   "Admin Key"
+// @Param        id           path      int                     true   "Tenant ID"
+// @Param        month        query     string                  false  "Month as YYYY-MM, defaults to the current UTC month"
+// @Success      200          {object}  dto.FeeReport           "Fee report"
+// @Failure      400          {object}  map[string]interface{}  "Invalid tenant ID or month"
+// @Router       /admin/tenants/{id}/fee-report [get]
+func (t *feeController) GetFeeReport(c *fiber.Ctx) error {
+	tenantID, err := c.ParamsInt("id")
+	if err != nil || tenantID <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid tenant ID",
+		})
+	}
+
+	report, err := t.feeService.GetMonthlyReport(uint(tenantID), c.Query("month", time.Now().UTC().Format("2006-01")))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(report)
+}
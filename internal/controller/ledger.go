@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"payment-broker/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type LedgerController interface {
+	GetLedgerBalances(c *fiber.Ctx) error
+	ListLedgerEntries(c *fiber.Ctx) error
+}
+
+type ledgerController struct {
+	logger        *zap.Logger
+	ledgerService service.LedgerService
+}
+
+func NewLedgerController(logger *zap.Logger, ledgerService service.LedgerService) LedgerController {
+	return &ledgerController{
+		logger:        logger,
+		ledgerService: ledgerService,
+	}
+}
+
+// GetLedgerBalances godoc
+// @Summary      Get Ledger Balances
+// @Description  A tenant's internal ledger balances per currency: XENDIT_BALANCE, TENANT_RECEIVABLE, PLATFORM_FEE_REVENUE, PAYOUTS_IN_FLIGHT and REFUNDS. When Xendit can be reached, xendit_balance is the sub-account CASH balance and difference is how far it is from XENDIT_BALANCE.
+// @Tags         admin
+// @Produce      json
+// @Param        X-Admin-Key  header    string                  true  "Admin Key"
+// @Param        id           path      int                     true  "Tenant ID"
+// @Success      200          {object}  map[string]interface{}  "Ledger balances"
+// @Failure      400          {object}  map[string]interface{}  "Invalid tenant ID"
+// @Failure      404          {object}  map[string]interface{}  "Tenant not found"
+// @Router       /admin/tenants/{id}/ledger [get]
+func (t *ledgerController) GetLedgerBalances(c *fiber.Ctx) error {
+	tenantID, err := c.ParamsInt("id")
+	if err != nil || tenantID <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid tenant ID",
+		})
+	}
+
+	balances, err := t.ledgerService.GetBalances(c.Context(), uint(tenantID))
+	if err != nil {
+		t.logger.Error("ledgerService.GetBalances", zap.Int("tenant_id", tenantID), zap.Error(err))
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Tenant not found",
+		})
+	}
+
+	return c.JSON(balances)
+}
+
+// ListLedgerEntries godoc
+// @Summary      List Ledger Entries
+// @Description  A tenant's ledger entries with their debit and credit lines, newest first
+// @Tags         admin
+// @Produce      json
+// @Param        X-Admin-Key  header    string                  true   "Admin Key"
+// @Param        id           path      int                     true   "Tenant ID"
+// @Param        limit        query     int                     false  "Number of entries, 50 by default"
+// @Param        before_id    query     int                     false  "Only entries with a lower ID, for the next page"
+// @Success      200          {array}   map[string]interface{}  "Ledger entries"
+// @Failure      400          {object}  map[string]interface{}  "Invalid tenant ID or query parameter"
+// @Failure      500          {object}  map[string]interface{}  "Failed to get ledger entries"
+// @Router       /admin/tenants/{id}/ledger/entries [get]
+func (t *ledgerController) ListLedgerEntries(c *fiber.Ctx) error {
+	tenantID, err := c.ParamsInt("id")
+	if err != nil || tenantID <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid tenant ID",
+		})
+	}
+
+	limit := c.QueryInt("limit", 50)
+	if limit < 1 || limit > 200 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "limit must be between 1 and 200",
+		})
+	}
+
+	beforeID := c.QueryInt("before_id", 0)
+	if beforeID < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "before_id must not be negative",
+		})
+	}
+
+	entries, err := t.ledgerService.GetEntries(uint(tenantID), uint(beforeID), limit)
+	if err != nil {
+		t.logger.Error("ledgerService.GetEntries", zap.Int("tenant_id", tenantID), zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get ledger entries",
+		})
+	}
+
+	return c.JSON(entries)
+}
@@ -0,0 +1,200 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"payment-broker/internal/helper"
+	"payment-broker/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type PayoutController interface {
+	GetPayout(c *fiber.Ctx) error
+	GetPayoutsByReference(c *fiber.Ctx) error
+	GetPayoutStatus(c *fiber.Ctx) error
+	CancelPayout(c *fiber.Ctx) error
+	GetPayoutChannels(c *fiber.Ctx) error
+}
+
+type payoutController struct {
+	logger        *zap.Logger
+	xenditService service.XenditService
+	payoutService service.PayoutService
+}
+
+func NewPayoutController(logger *zap.Logger, xenditService service.XenditService, payoutService service.PayoutService) PayoutController {
+	return &payoutController{
+		logger:        logger,
+		xenditService: xenditService,
+		payoutService: payoutService,
+	}
+}
+
+// GetPayout godoc
+// @Summary      Get Payout
+// @Description  Get a payout by ID via Xendit
+// @Tags         action
+// @Produce      json
+// @Param        X-Api-Key  header    string                  true  "API Key"
+// @Param        id         path      string                  true  "Xendit payout ID"
+// @Success      200        {object}  map[string]interface{}  "Payout retrieved successfully"
+// @Failure      404        {object}  map[string]interface{}  "Payout not found"
+// @Failure      502        {object}  map[string]interface{}  "Failed to get payout"
+// @Router       /xendit/action/payouts/{id} [get]
+func (t *payoutController) GetPayout(c *fiber.Ctx) error {
+	tenantID := c.Locals("X-Tenant-ID").(string)
+	accountID := c.Locals("X-Account-ID").(string)
+
+	resp, err := t.xenditService.GetRequest(c.Context(), fmt.Sprintf("/v2/payouts/%s", url.PathEscape(c.Params("id"))), nil, accountID)
+	if err != nil {
+		t.logger.Error("Xendit API error", zap.Error(err))
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
+			"error": "Failed to get payout",
+		})
+	}
+
+	if resp.StatusCode == http.StatusOK && !t.payoutService.OwnsPayout(tenantID, resp) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Payout not found",
+		})
+	}
+
+	return sendXenditResponse(c, resp)
+}
+
+// GetPayoutsByReference godoc
+// @Summary      Get Payouts by Reference
+// @Description  Get payouts by reference_id via Xendit
+// @Tags         action
+// @Produce      json
+// @Param        X-Api-Key     header    string                  true  "API Key"
+// @Param        reference_id  query     string                  true  "Reference ID without the tenant prefix"
+// @Success      200           {array}   map[string]interface{}  "Payouts retrieved successfully"
+// @Failure      400           {object}  map[string]interface{}  "Missing reference_id"
+// @Failure      502           {object}  map[string]interface{}  "Failed to get payouts"
+// @Router       /xendit/action/payouts [get]
+func (t *payoutController) GetPayoutsByReference(c *fiber.Ctx) error {
+	tenantID := c.Locals("X-Tenant-ID").(string)
+	accountID := c.Locals("X-Account-ID").(string)
+
+	referenceID := c.Query("reference_id")
+	if referenceID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "reference_id is required",
+		})
+	}
+
+	query := url.Values{}
+	query.Set("reference_id", helper.TenantReference(tenantID, referenceID))
+
+	resp, err := t.xenditService.GetRequest(c.Context(), "/v2/payouts", query, accountID)
+	if err != nil {
+		t.logger.Error("Xendit API error", zap.Error(err))
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
+			"error": "Failed to get payouts",
+		})
+	}
+
+	return sendXenditResponse(c, resp)
+}
+
+// GetPayoutStatus godoc
+// @Summary      Get Payout Status
+// @Description  Get the payout status and failure code recorded by the broker from Xendit webhooks
+// @Tags         action
+// @Produce      json
+// @Param        X-Api-Key  header    string                  true  "API Key"
+// @Param        id         path      string                  true  "Xendit payout ID"
+// @Success      200        {object}  map[string]interface{}  "Payout status"
+// @Failure      404        {object}  map[string]interface{}  "Payout not found"
+// @Router       /xendit/action/payouts/{id}/status [get]
+func (t *payoutController) GetPayoutStatus(c *fiber.Ctx) error {
+	tenantID := c.Locals("X-Tenant-ID").(string)
+
+	payout, err := t.payoutService.GetPayout(tenantID, c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Payout not found",
+		})
+	}
+
+	return c.JSON(payout)
+}
+
+// CancelPayout godoc
+// @Summary      Cancel Payout
+// @Description  Cancel a pending payout via Xendit
+// @Tags         action
+// @Produce      json
+// @Param        X-Api-Key  header    string                  true  "API Key"
+// @Param        id         path      string                  true  "Xendit payout ID"
+// @Success      200        {object}  map[string]interface{}  "Payout cancelled successfully"
+// @Failure      404        {object}  map[string]interface{}  "Payout not found"
+// @Failure      502        {object}  map[string]interface{}  "Failed to cancel payout"
+// @Router       /xendit/action/payouts/{id}/cancel [post]
+func (t *payoutController) CancelPayout(c *fiber.Ctx) error {
+	tenantID := c.Locals("X-Tenant-ID").(string)
+	accountID := c.Locals("X-Account-ID").(string)
+	payoutPath := fmt.Sprintf("/v2/payouts/%s", url.PathEscape(c.Params("id")))
+
+	payout, err := t.xenditService.GetRequest(c.Context(), payoutPath, nil, accountID)
+	if err != nil {
+		t.logger.Error("Xendit API error", zap.Error(err))
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
+			"error": "Failed to cancel payout",
+		})
+	}
+
+	if payout.StatusCode != http.StatusOK || !t.payoutService.OwnsPayout(tenantID, payout) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Payout not found",
+		})
+	}
+
+	resp, err := t.xenditService.CreateRequest(c.Context(), nil, payoutPath+"/cancel", accountID, "")
+	if err != nil {
+		t.logger.Error("Xendit API error", zap.Error(err))
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
+			"error": "Failed to cancel payout",
+		})
+	}
+
+	t.payoutService.RecordPayout(tenantID, resp)
+	return sendXenditResponse(c, resp)
+}
+
+// GetPayoutChannels godoc
+// @Summary      List Payout Channels
+// @Description  List the payout channels available to the tenant sub-account via Xendit
+// @Tags         action
+// @Produce      json
+// @Param        X-Api-Key         header    string                  true   "API Key"
+// @Param        currency          query     string                  false  "Currency code"
+// @Param        channel_category  query     string                  false  "BANK or EWALLET"
+// @Param        channel_code      query     string                  false  "Channel code"
+// @Success      200               {array}   map[string]interface{}  "Payout channels"
+// @Failure      502               {object}  map[string]interface{}  "Failed to get payout channels"
+// @Router       /xendit/action/payouts_channels [get]
+func (t *payoutController) GetPayoutChannels(c *fiber.Ctx) error {
+	accountID := c.Locals("X-Account-ID").(string)
+
+	query := url.Values{}
+	for _, key := range []string{"currency", "channel_category", "channel_code"} {
+		if value := c.Query(key); value != "" {
+			query.Set(key, value)
+		}
+	}
+
+	resp, err := t.xenditService.GetRequest(c.Context(), "/payouts_channels", query, accountID)
+	if err != nil {
+		t.logger.Error("Xendit API error", zap.Error(err))
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
+			"error": "Failed to get payout channels",
+		})
+	}
+
+	return sendXenditResponse(c, resp)
+}
@@ -0,0 +1,213 @@
+package controller
+
+import (
+	model "payment-broker/internal/model/db"
+	"payment-broker/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type PayoutApprovalController interface {
+	ListApprovals(c *fiber.Ctx) error
+	GetApproval(c *fiber.Ctx) error
+	ApprovePayout(c *fiber.Ctx) error
+	RejectPayout(c *fiber.Ctx) error
+	GetTenantApproval(c *fiber.Ctx) error
+}
+
+type payoutApprovalController struct {
+	logger                *zap.Logger
+	payoutApprovalService service.PayoutApprovalService
+}
+
+func NewPayoutApprovalController(logger *zap.Logger, payoutApprovalService service.PayoutApprovalService) PayoutApprovalController {
+	return &payoutApprovalController{
+		logger:                logger,
+		payoutApprovalService: payoutApprovalService,
+	}
+}
+
+// ListApprovals godoc
+// @Summary      List Payout Approvals
+// @Description  List payout approvals, pending ones by default
+// @Tags         admin
+// @Produce      json
+// @Param        X-Admin-Key  header    string                  true   "Admin Key"
+// @Param        status       query     string                  false  "PENDING_APPROVAL, APPROVED, REJECTED or FAILED"
+// @Success      200          {array}   map[string]interface{}  "Payout approvals"
+// @Failure      500          {object}  map[string]interface{}  "Failed to get payout approvals"
+// @Router       /admin/payout-approvals [get]
+func (t *payoutApprovalController) ListApprovals(c *fiber.Ctx) error {
+	approvals, err := t.payoutApprovalService.GetApprovals(c.Query("status", "PENDING_APPROVAL"))
+	if err != nil {
+		t.logger.Error("payoutApprovalService.GetApprovals", zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get payout approvals",
+		})
+	}
+
+	return c.JSON(approvals)
+}
+
+// GetApproval godoc
+// @Summary      Get Payout Approval
+// @Description  Get a payout approval with its audit trail
+// @Tags         admin
+// @Produce      json
+// @Param        X-Admin-Key  header    string                  true  "Admin Key"
+// @Param        id           path      int                     true  "Approval ID"
+// @Success      200          {object}  map[string]interface{}  "Payout approval"
+// @Failure      404          {object}  map[string]interface{}  "Payout approval not found"
+// @Router       /admin/payout-approvals/{id} [get]
+func (t *payoutApprovalController) GetApproval(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid approval ID",
+		})
+	}
+
+	approval, err := t.payoutApprovalService.GetApproval(uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Payout approval not found",
+		})
+	}
+
+	history, err := t.payoutApprovalService.GetHistory(uint(id))
+	if err != nil {
+		t.logger.Error("payoutApprovalService.GetHistory", zap.Int("id", id), zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get payout approval history",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"approval": approval,
+		"history":  history,
+	})
+}
+
+// adminUser is the name of the admin whose personal key authenticated the
+// request, or empty for the shared ADMIN_API_KEY.
+func adminUser(c *fiber.Ctx) string {
+	name, _ := c.Locals("X-Admin-User").(string)
+	return name
+}
+
+// ApprovePayout godoc
+// @Summary      Approve Payout
+// @Description  Approve a held payout and send it to Xendit
+// @Tags         admin
+// @Produce      json
+// @Param        X-Admin-Key   header    string                  true  "Personal admin key from ADMIN_API_KEYS"
+// @Param        id            path      int                     true  "Approval ID"
+// @Success      200           {object}  map[string]interface{}  "Payout approved"
+// @Failure      400           {object}  map[string]interface{}  "Payout can't be approved"
+// @Failure      502           {object}  map[string]interface{}  "Approved but Xendit rejected the payout"
+// @Router       /admin/payout-approvals/{id}/approve [post]
+func (t *payoutApprovalController) ApprovePayout(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid approval ID",
+		})
+	}
+
+	approval, resp, err := t.payoutApprovalService.Approve(c.Context(), uint(id), adminUser(c))
+	if err != nil {
+		if approval == nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		t.logger.Error("payoutApprovalService.Approve", zap.Int("id", id), zap.Error(err))
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
+			"error":    err.Error(),
+			"approval": approval,
+		})
+	}
+
+	if approval.Status != model.PayoutApprovalApproved {
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
+			"error":    approval.Reason,
+			"approval": approval,
+			"xendit":   string(resp.Body),
+		})
+	}
+
+	return c.JSON(approval)
+}
+
+// RejectPayout godoc
+// @Summary      Reject Payout
+// @Description  Reject a held payout so it is never sent
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        X-Admin-Key   header    string                  true  "Personal admin key from ADMIN_API_KEYS"
+// @Param        id            path      int                     true  "Approval ID"
+// @Param        body          body      map[string]interface{}  false "Rejection reason, e.g. {\"reason\": \"...\"}"
+// @Success      200           {object}  map[string]interface{}  "Payout rejected"
+// @Failure      400           {object}  map[string]interface{}  "Payout can't be rejected"
+// @Router       /admin/payout-approvals/{id}/reject [post]
+func (t *payoutApprovalController) RejectPayout(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid approval ID",
+		})
+	}
+
+	var body struct {
+		Reason string `json:"reason"`
+	}
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&body); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid request body",
+			})
+		}
+	}
+
+	approval, err := t.payoutApprovalService.Reject(uint(id), adminUser(c), body.Reason)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(approval)
+}
+
+// GetTenantApproval godoc
+// @Summary      Get Payout Approval
+// @Description  Get the review status of a payout held for approval
+// @Tags         action
+// @Produce      json
+// @Param        X-Api-Key  header    string                  true  "API Key"
+// @Param        id         path      int                     true  "Approval ID"
+// @Success      200        {object}  map[string]interface{}  "Payout approval"
+// @Failure      404        {object}  map[string]interface{}  "Payout approval not found"
+// @Router       /xendit/action/payout-approvals/{id} [get]
+func (t *payoutApprovalController) GetTenantApproval(c *fiber.Ctx) error {
+	tenantID := c.Locals("X-Tenant-ID").(string)
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid approval ID",
+		})
+	}
+
+	approval, err := t.payoutApprovalService.GetTenantApproval(tenantID, uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Payout approval not found",
+		})
+	}
+
+	return c.JSON(approval)
+}
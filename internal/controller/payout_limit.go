@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"payment-broker/internal/model/dto"
+	"payment-broker/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type PayoutLimitController interface {
+	GetPayoutLimit(c *fiber.Ctx) error
+	SetPayoutLimit(c *fiber.Ctx) error
+}
+
+type payoutLimitController struct {
+	logger             *zap.Logger
+	payoutLimitService service.PayoutLimitService
+}
+
+func NewPayoutLimitController(logger *zap.Logger, payoutLimitService service.PayoutLimitService) PayoutLimitController {
+	return &payoutLimitController{
+		logger:             logger,
+		payoutLimitService: payoutLimitService,
+	}
+}
+
+// GetPayoutLimit godoc
+// @Summary      Get Payout Limits
+// @Description  Get a tenant's payout limits, zero means no limit
+// @Tags         admin
+// @Produce      json
+// @Param        X-Admin-Key  header    string                  true  "Admin Key"
+// @Param        id           path      int                     true  "Tenant ID"
+// @Success      200          {object}  map[string]interface{}  "Tenant payout limits"
+// @Failure      400          {object}  map[string]interface{}  "Invalid tenant ID"
+// @Failure      500          {object}  map[string]interface{}  "Failed to get payout limits"
+// @Router       /admin/tenants/{id}/payout-limits [get]
+func (t *payoutLimitController) GetPayoutLimit(c *fiber.Ctx) error {
+	tenantID, err := c.ParamsInt("id")
+	if err != nil || tenantID <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid tenant ID",
+		})
+	}
+
+	limit, err := t.payoutLimitService.GetLimit(uint(tenantID))
+	if err != nil {
+		t.logger.Error("payoutLimitService.GetLimit", zap.Int("tenant_id", tenantID), zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get payout limits",
+		})
+	}
+
+	return c.JSON(limit)
+}
+
+// SetPayoutLimit godoc
+// @Summary      Set Payout Limits
+// @Description  Replace a tenant's payout limits, zero means no limit
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        X-Admin-Key  header    string                  true  "Admin Key"
+// @Param        id           path      int                     true  "Tenant ID"
+// @Param        body         body      dto.PayoutLimitRequest  true  "Payout limits"
+// @Success      200          {object}  map[string]interface{}  "Payout limits saved successfully"
+// @Failure      400          {object}  map[string]interface{}  "Invalid request body"
+// @Router       /admin/tenants/{id}/payout-limits [put]
+func (t *payoutLimitController) SetPayoutLimit(c *fiber.Ctx) error {
+	tenantID, err := c.ParamsInt("id")
+	if err != nil || tenantID <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid tenant ID",
+		})
+	}
+
+	var body dto.PayoutLimitRequest
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	limit, err := t.payoutLimitService.SetLimit(uint(tenantID), body)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(limit)
+}
@@ -0,0 +1,135 @@
+package controller
+
+import (
+	"context"
+	"payment-broker/internal/model/dto"
+	"payment-broker/internal/service"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type ReconciliationController interface {
+	StartReconciliation(c *fiber.Ctx) error
+	ListReconciliations(c *fiber.Ctx) error
+	GetReconciliation(c *fiber.Ctx) error
+}
+
+type reconciliationController struct {
+	logger                *zap.Logger
+	reconciliationService service.ReconciliationService
+}
+
+func NewReconciliationController(logger *zap.Logger, reconciliationService service.ReconciliationService) ReconciliationController {
+	return &reconciliationController{
+		logger:                logger,
+		reconciliationService: reconciliationService,
+	}
+}
+
+// StartReconciliation godoc
+// @Summary      Start Reconciliation
+// @Description  Compare every tenant's invoices and payouts at Xendit with the broker's records for a window, the last 24 hours by default. The run continues in the background.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        X-Admin-Key  header    string                     true   "Admin Key"
+// @Param        body         body      dto.ReconciliationRequest  false  "Window as RFC3339 from/to and whether to self-heal statuses"
+// @Success      202          {object}  map[string]interface{}     "Reconciliation started"
+// @Failure      400          {object}  map[string]interface{}     "Invalid window"
+// @Failure      409          {object}  map[string]interface{}     "A reconciliation run is already in progress"
+// @Router       /admin/reconciliations [post]
+func (t *reconciliationController) StartReconciliation(c *fiber.Ctx) error {
+	var body dto.ReconciliationRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&body); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid request body",
+			})
+		}
+	}
+
+	if body.To.IsZero() {
+		body.To = time.Now()
+	}
+	if body.From.IsZero() {
+		body.From = body.To.Add(-24 * time.Hour)
+	}
+	if !body.From.Before(body.To) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "from must be before to",
+		})
+	}
+
+	run, err := t.reconciliationService.Begin(body.From, body.To, body.SelfHeal)
+	if err != nil {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	go t.reconciliationService.Execute(context.Background(), run)
+
+	return c.Status(fiber.StatusAccepted).JSON(run)
+}
+
+// ListReconciliations godoc
+// @Summary      List Reconciliations
+// @Description  List the latest reconciliation runs
+// @Tags         admin
+// @Produce      json
+// @Param        X-Admin-Key  header    string                  true   "Admin Key"
+// @Param        limit        query     int                     false  "Number of runs, 20 by default"
+// @Success      200          {array}   map[string]interface{}  "Reconciliation runs"
+// @Failure      500          {object}  map[string]interface{}  "Failed to get reconciliation runs"
+// @Router       /admin/reconciliations [get]
+func (t *reconciliationController) ListReconciliations(c *fiber.Ctx) error {
+	limit := c.QueryInt("limit", 20)
+	if limit < 1 || limit > 100 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "limit must be between 1 and 100",
+		})
+	}
+
+	runs, err := t.reconciliationService.GetRuns(limit)
+	if err != nil {
+		t.logger.Error("reconciliationService.GetRuns", zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get reconciliation runs",
+		})
+	}
+
+	return c.JSON(runs)
+}
+
+// GetReconciliation godoc
+// @Summary      Get Reconciliation Report
+// @Description  Get a reconciliation run with its mismatches: MISSING_LOCALLY, MISSING_WEBHOOK, STATUS_DRIFT or AMOUNT_DRIFT
+// @Tags         admin
+// @Produce      json
+// @Param        X-Admin-Key  header    string                  true  "Admin Key"
+// @Param        id           path      int                     true  "Run ID"
+// @Success      200          {object}  map[string]interface{}  "Reconciliation report"
+// @Failure      404          {object}  map[string]interface{}  "Reconciliation run not found"
+// @Router       /admin/reconciliations/{id} [get]
+func (t *reconciliationController) GetReconciliation(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid run ID",
+		})
+	}
+
+	run, mismatches, err := t.reconciliationService.GetRun(uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Reconciliation run not found",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"run":        run,
+		"mismatches": mismatches,
+	})
+}
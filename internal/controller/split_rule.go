@@ -0,0 +1,156 @@
+package controller
+
+import (
+	"payment-broker/internal/model/dto"
+	"payment-broker/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type SplitRuleController interface {
+	CreateSplitRule(c *fiber.Ctx) error
+	AttachSplitRule(c *fiber.Ctx) error
+	DetachSplitRule(c *fiber.Ctx) error
+	GetTenantSplitRules(c *fiber.Ctx) error
+}
+
+type splitRuleController struct {
+	logger           *zap.Logger
+	splitRuleService service.SplitRuleService
+}
+
+func NewSplitRuleController(logger *zap.Logger, splitRuleService service.SplitRuleService) SplitRuleController {
+	return &splitRuleController{
+		logger:           logger,
+		splitRuleService: splitRuleService,
+	}
+}
+
+// CreateSplitRule godoc
+// @Summary      Create Split Rule
+// @Description  Create a split rule on the master account via Xendit
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        X-Admin-Key  header    string                     true  "Admin Key"
+// @Param        body         body      dto.XenditCreateSplitRule  true  "Split rule payload"
+// @Success      201          {object}  dto.XenditSplitRule        "Split rule created successfully"
+// @Failure      400          {object}  map[string]interface{}     "Invalid request body"
+// @Failure      502          {object}  map[string]interface{}     "Failed to create split rule"
+// @Router       /admin/split-rules [post]
+func (t *splitRuleController) CreateSplitRule(c *fiber.Ctx) error {
+	var body dto.XenditCreateSplitRule
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	rule, err := t.splitRuleService.CreateSplitRule(c.Context(), body)
+	if err != nil {
+		t.logger.Error("splitRuleService.CreateSplitRule", zap.Error(err))
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(rule)
+}
+
+// AttachSplitRule godoc
+// @Summary      Attach Split Rule
+// @Description  Attach a split rule to a tenant, optionally for a single endpoint
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        X-Admin-Key  header    string                  true  "Admin Key"
+// @Param        id           path      int                     true  "Tenant ID"
+// @Param        body         body      dto.AttachSplitRule     true  "Split rule attachment"
+// @Success      200          {object}  map[string]interface{}  "Split rule attached successfully"
+// @Failure      400          {object}  map[string]interface{}  "Invalid request body"
+// @Router       /admin/tenants/{id}/split-rules [put]
+func (t *splitRuleController) AttachSplitRule(c *fiber.Ctx) error {
+	tenantID, err := c.ParamsInt("id")
+	if err != nil || tenantID <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid tenant ID",
+		})
+	}
+
+	var body dto.AttachSplitRule
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	rule, err := t.splitRuleService.AttachSplitRule(uint(tenantID), body)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(rule)
+}
+
+// DetachSplitRule godoc
+// @Summary      Detach Split Rule
+// @Description  Remove a tenant split rule; omit endpoint to remove the tenant default
+// @Tags         admin
+// @Produce      json
+// @Param        X-Admin-Key  header    string                  true   "Admin Key"
+// @Param        id           path      int                     true   "Tenant ID"
+// @Param        endpoint     query     string                  false  "Xendit endpoint, e.g. /v2/invoices"
+// @Success      204
+// @Failure      400          {object}  map[string]interface{}  "Invalid tenant ID"
+// @Failure      500          {object}  map[string]interface{}  "Failed to detach split rule"
+// @Router       /admin/tenants/{id}/split-rules [delete]
+func (t *splitRuleController) DetachSplitRule(c *fiber.Ctx) error {
+	tenantID, err := c.ParamsInt("id")
+	if err != nil || tenantID <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid tenant ID",
+		})
+	}
+
+	if err := t.splitRuleService.DetachSplitRule(uint(tenantID), c.Query("endpoint")); err != nil {
+		t.logger.Error("splitRuleService.DetachSplitRule", zap.Int("tenant_id", tenantID), zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to detach split rule",
+		})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// GetTenantSplitRules godoc
+// @Summary      List Tenant Split Rules
+// @Description  List the split rules attached to a tenant
+// @Tags         admin
+// @Produce      json
+// @Param        X-Admin-Key  header    string                  true  "Admin Key"
+// @Param        id           path      int                     true  "Tenant ID"
+// @Success      200          {array}   map[string]interface{}  "Tenant split rules"
+// @Failure      400          {object}  map[string]interface{}  "Invalid tenant ID"
+// @Failure      500          {object}  map[string]interface{}  "Failed to get split rules"
+// @Router       /admin/tenants/{id}/split-rules [get]
+func (t *splitRuleController) GetTenantSplitRules(c *fiber.Ctx) error {
+	tenantID, err := c.ParamsInt("id")
+	if err != nil || tenantID <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid tenant ID",
+		})
+	}
+
+	rules, err := t.splitRuleService.GetTenantSplitRules(uint(tenantID))
+	if err != nil {
+		t.logger.Error("splitRuleService.GetTenantSplitRules", zap.Int("tenant_id", tenantID), zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get split rules",
+		})
+	}
+
+	return c.JSON(rules)
+}
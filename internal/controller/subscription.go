@@ -0,0 +1,186 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"payment-broker/internal/helper"
+	"payment-broker/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type SubscriptionController interface {
+	ListPlans(c *fiber.Ctx) error
+	GetPlan(c *fiber.Ctx) error
+	UpdatePlan(c *fiber.Ctx) error
+	DeactivatePlan(c *fiber.Ctx) error
+	ListCycles(c *fiber.Ctx) error
+}
+
+type subscriptionController struct {
+	logger              *zap.Logger
+	xenditService       service.XenditService
+	subscriptionService service.SubscriptionService
+}
+
+func NewSubscriptionController(logger *zap.Logger, xenditService service.XenditService,
+	subscriptionService service.SubscriptionService) SubscriptionController {
+	return &subscriptionController{
+		logger:              logger,
+		xenditService:       xenditService,
+		subscriptionService: subscriptionService,
+	}
+}
+
+// ListPlans godoc
+// @Summary      List Subscription Plans
+// @Description  List the tenant's recurring plans as stored by the broker
+// @Tags         action
+// @Produce      json
+// @Param        X-Api-Key  header    string                    true   "API Key"
+// @Param        status     query     string                    false  "Plan status, e.g. ACTIVE"
+// @Success      200        {array}   map[string]interface{}    "Subscription plans"
+// @Failure      500        {object}  map[string]interface{}    "Failed to get plans"
+// @Router       /xendit/action/recurring/plans [get]
+func (t *subscriptionController) ListPlans(c *fiber.Ctx) error {
+	tenantID := c.Locals("X-Tenant-ID").(string)
+
+	plans, err := t.subscriptionService.GetPlans(tenantID, c.Query("status"))
+	if err != nil {
+		t.logger.Error("subscriptionService.GetPlans", zap.String("tenant_id", tenantID), zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get plans",
+		})
+	}
+
+	return c.JSON(plans)
+}
+
+// GetPlan godoc
+// @Summary      Get Subscription Plan
+// @Description  Get a recurring plan as stored by the broker
+// @Tags         action
+// @Produce      json
+// @Param        X-Api-Key  header    string                  true  "API Key"
+// @Param        id         path      string                  true  "Xendit plan ID"
+// @Success      200        {object}  map[string]interface{}  "Subscription plan"
+// @Failure      404        {object}  map[string]interface{}  "Plan not found"
+// @Router       /xendit/action/recurring/plans/{id} [get]
+func (t *subscriptionController) GetPlan(c *fiber.Ctx) error {
+	tenantID := c.Locals("X-Tenant-ID").(string)
+
+	plan, err := t.subscriptionService.GetPlan(tenantID, c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Plan not found",
+		})
+	}
+
+	return c.JSON(plan)
+}
+
+// UpdatePlan godoc
+// @Summary      Update Subscription Plan
+// @Description  Update a recurring plan via Xendit
+// @Tags         action
+// @Accept       json
+// @Produce      json
+// @Param        X-Api-Key  header    string                  true  "API Key"
+// @Param        id         path      string                  true  "Xendit plan ID"
+// @Param        body       body      map[string]interface{}  true  "Plan update payload"
+// @Success      200        {object}  map[string]interface{}  "Subscription plan updated successfully"
+// @Failure      400        {object}  map[string]interface{}  "Invalid request body"
+// @Failure      404        {object}  map[string]interface{}  "Plan not found"
+// @Failure      502        {object}  map[string]interface{}  "Failed to update subscription"
+// @Router       /xendit/action/recurring/plans/{id} [patch]
+func (t *subscriptionController) UpdatePlan(c *fiber.Ctx) error {
+	tenantID := c.Locals("X-Tenant-ID").(string)
+	accountID := c.Locals("X-Account-ID").(string)
+
+	plan, err := t.subscriptionService.GetPlan(tenantID, c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Plan not found",
+		})
+	}
+
+	var data map[string]interface{}
+	if err := json.Unmarshal(c.Body(), &data); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if referenceID, ok := data["reference_id"].(string); ok && referenceID != "" {
+		data["reference_id"] = helper.TenantReference(tenantID, referenceID)
+	}
+
+	resp, err := t.xenditService.UpdateRequest(c.Context(), data, fmt.Sprintf("/recurring/plans/%s", plan.PlanID), accountID)
+	if err != nil {
+		t.logger.Error("Xendit API error", zap.Error(err))
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
+			"error": "Failed to update subscription",
+		})
+	}
+
+	t.subscriptionService.RecordPlan(tenantID, resp)
+	return sendXenditResponse(c, resp)
+}
+
+// DeactivatePlan godoc
+// @Summary      Deactivate Subscription Plan
+// @Description  Deactivate a recurring plan via Xendit
+// @Tags         action
+// @Produce      json
+// @Param        X-Api-Key  header    string                  true  "API Key"
+// @Param        id         path      string                  true  "Xendit plan ID"
+// @Success      200        {object}  map[string]interface{}  "Subscription plan deactivated successfully"
+// @Failure      404        {object}  map[string]interface{}  "Plan not found"
+// @Failure      502        {object}  map[string]interface{}  "Failed to deactivate subscription"
+// @Router       /xendit/action/recurring/plans/{id}/deactivate [post]
+func (t *subscriptionController) DeactivatePlan(c *fiber.Ctx) error {
+	tenantID := c.Locals("X-Tenant-ID").(string)
+	accountID := c.Locals("X-Account-ID").(string)
+
+	plan, err := t.subscriptionService.GetPlan(tenantID, c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Plan not found",
+		})
+	}
+
+	resp, err := t.xenditService.CreateRequest(c.Context(), nil, fmt.Sprintf("/recurring/plans/%s/deactivate", plan.PlanID), accountID, "")
+	if err != nil {
+		t.logger.Error("Xendit API error", zap.Error(err))
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
+			"error": "Failed to deactivate subscription",
+		})
+	}
+
+	t.subscriptionService.RecordPlan(tenantID, resp)
+	return sendXenditResponse(c, resp)
+}
+
+// ListCycles godoc
+// @Summary      List Subscription Cycles
+// @Description  List the cycles of a recurring plan as recorded from Xendit webhooks
+// @Tags         action
+// @Produce      json
+// @Param        X-Api-Key  header    string                    true  "API Key"
+// @Param        id         path      string                    true  "Xendit plan ID"
+// @Success      200        {array}   map[string]interface{}    "Subscription cycles"
+// @Failure      404        {object}  map[string]interface{}    "Plan not found"
+// @Router       /xendit/action/recurring/plans/{id}/cycles [get]
+func (t *subscriptionController) ListCycles(c *fiber.Ctx) error {
+	tenantID := c.Locals("X-Tenant-ID").(string)
+
+	cycles, err := t.subscriptionService.GetCycles(tenantID, c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Plan not found",
+		})
+	}
+
+	return c.JSON(cycles)
+}
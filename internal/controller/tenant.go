@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"payment-broker/internal/model/dto"
+	"payment-broker/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type TenantController interface {
+	GetTenantCurrencies(c *fiber.Ctx) error
+	SetTenantCurrencies(c *fiber.Ctx) error
+}
+
+type tenantController struct {
+	logger        *zap.Logger
+	tenantService service.TenantService
+}
+
+func NewTenantController(logger *zap.Logger, tenantService service.TenantService) TenantController {
+	return &tenantController{
+		logger:        logger,
+		tenantService: tenantService,
+	}
+}
+
+// GetTenantCurrencies godoc
+// @Summary      Get Tenant Currencies
+// @Description  Get the currencies a tenant may use in invoices, payouts and plans, and the default applied when a request has no currency
+// @Tags         admin
+// @Produce      json
+// @Param        X-Admin-Key  header    string                  true  "Admin Key"
+// @Param        id           path      int                     true  "Tenant ID"
+// @Success      200          {object}  map[string]interface{}  "Tenant currencies"
+// @Failure      400          {object}  map[string]interface{}  "Invalid tenant ID"
+// @Failure      404          {object}  map[string]interface{}  "Tenant not found"
+// @Router       /admin/tenants/{id}/currencies [get]
+func (t *tenantController) GetTenantCurrencies(c *fiber.Ctx) error {
+	tenantID, err := c.ParamsInt("id")
+	if err != nil || tenantID <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid tenant ID",
+		})
+	}
+
+	currencies, err := t.tenantService.GetCurrencies(uint(tenantID))
+	if err != nil {
+		t.logger.Error("tenantService.GetCurrencies", zap.Int("tenant_id", tenantID), zap.Error(err))
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Tenant not found",
+		})
+	}
+
+	return c.JSON(currencies)
+}
+
+// SetTenantCurrencies godoc
+// @Summary      Set Tenant Currencies
+// @Description  Replace a tenant's allowed currencies and default currency. An empty list allows every currency Xendit supports, and an empty default uses the first allowed currency, or IDR.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        X-Admin-Key  header    string                       true  "Admin Key"
+// @Param        id           path      int                          true  "Tenant ID"
+// @Param        body         body      dto.TenantCurrenciesRequest  true  "Tenant currencies"
+// @Success      200          {object}  map[string]interface{}       "Tenant currencies saved successfully"
+// @Failure      400          {object}  map[string]interface{}       "Invalid request body"
+// @Router       /admin/tenants/{id}/currencies [put]
+func (t *tenantController) SetTenantCurrencies(c *fiber.Ctx) error {
+	tenantID, err := c.ParamsInt("id")
+	if err != nil || tenantID <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid tenant ID",
+		})
+	}
+
+	var body dto.TenantCurrenciesRequest
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	currencies, err := t.tenantService.SetCurrencies(uint(tenantID), body)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(currencies)
+}
@@ -2,7 +2,9 @@ package controller
 
 import (
 	"encoding/json"
+	"payment-broker/internal/model/dto"
 	"payment-broker/internal/service"
+	"strconv"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
@@ -14,14 +16,30 @@ type WebhookController interface {
 }
 
 type webhookController struct {
-	logger        *zap.Logger
-	xenditService service.XenditService
+	logger              *zap.Logger
+	xenditService       service.XenditService
+	tenantService       service.TenantService
+	subscriptionService service.SubscriptionService
+	payoutService       service.PayoutService
+	invoiceService      service.InvoiceService
+	feeService          service.FeeService
+	ledgerService       service.LedgerService
+	eventLogService     service.EventLogService
 }
 
-func NewWebhookController(logger *zap.Logger, xenditService service.XenditService) WebhookController {
+func NewWebhookController(logger *zap.Logger, xenditService service.XenditService, tenantService service.TenantService,
+	subscriptionService service.SubscriptionService, payoutService service.PayoutService, invoiceService service.InvoiceService,
+	feeService service.FeeService, ledgerService service.LedgerService, eventLogService service.EventLogService) WebhookController {
 	return &webhookController{
-		logger:        logger,
-		xenditService: xenditService,
+		logger:              logger,
+		xenditService:       xenditService,
+		tenantService:       tenantService,
+		subscriptionService: subscriptionService,
+		payoutService:       payoutService,
+		invoiceService:      invoiceService,
+		feeService:          feeService,
+		ledgerService:       ledgerService,
+		eventLogService:     eventLogService,
 	}
 }
 
@@ -49,6 +67,10 @@ func (t *webhookController) WebhookHandler(c *fiber.Ctx) error {
 		})
 	}
 
+	if event, ok := body["event"].(string); ok && strings.HasPrefix(event, "account.") {
+		return t.handleAccountEvent(c, rawBody)
+	}
+
 	var tenantID string
 
 	if _, hasEvent := body["event"]; hasEvent {
@@ -61,10 +83,11 @@ func (t *webhookController) WebhookHandler(c *fiber.Ctx) error {
 			}
 		}
 	} else {
-		if referenceID, ok := body["reference_id"].(string); ok && referenceID != "" {
-			parts := strings.Split(referenceID, ":")
-			if len(parts) > 0 {
-				tenantID = parts[0]
+		// Legacy invoice callbacks carry the tenant prefix in external_id.
+		for _, key := range []string{"reference_id", "external_id"} {
+			if referenceID, ok := body[key].(string); ok && referenceID != "" {
+				tenantID, _, _ = strings.Cut(referenceID, ":")
+				break
 			}
 		}
 	}
@@ -76,5 +99,111 @@ func (t *webhookController) WebhookHandler(c *fiber.Ctx) error {
 		})
 	}
 
-	return t.xenditService.ProxyWebhook(c.Context(), tenantID, rawBody, apiKey)
+	if event, ok := body["event"].(string); ok {
+		var payload dto.XenditWebhookEvent
+		if err := json.Unmarshal(rawBody, &payload); err != nil {
+			t.logger.Error("Failed to parse event webhook", zap.Error(err))
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid webhook body",
+			})
+		}
+
+		// Store the state before proxying so a failure here makes Xendit retry
+		// instead of leaving the broker behind what the tenant already saw.
+		if err := t.recordEvent(tenantID, payload); err != nil {
+			t.logger.Error("Failed to record webhook event", zap.String("tenant_id", tenantID), zap.String("event", event), zap.Error(err))
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to record webhook event",
+			})
+		}
+	} else if _, isInvoice := body["external_id"]; isInvoice {
+		if err := t.recordInvoice(tenantID, rawBody); err != nil {
+			t.logger.Error("Failed to record invoice callback", zap.String("tenant_id", tenantID), zap.Error(err))
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to record webhook event",
+			})
+		}
+	}
+
+	id, err := strconv.ParseUint(tenantID, 10, 32)
+	if err != nil {
+		t.logger.Error("Invalid tenant_id in webhook", zap.String("tenant_id", tenantID))
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Missing tenant identifier in webhook",
+		})
+	}
+
+	// Log the event first so streams and the message bus relay see everything
+	// webhooks are sent, and a failure makes Xendit retry.
+	routed := service.NewEvent(uint(id), rawBody)
+	if err := t.eventLogService.Record(routed); err != nil {
+		t.logger.Error("eventLogService.Record", zap.String("tenant_id", tenantID), zap.String("event_id", routed.ID), zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to record webhook event",
+		})
+	}
+
+	return t.xenditService.ProxyWebhook(c.Context(), routed, apiKey)
+}
+
+// recordEvent updates the broker's own records from the event. Services skip
+// payloads they can't read, so only storage errors fail the callback.
+func (t *webhookController) recordEvent(tenantID string, payload dto.XenditWebhookEvent) error {
+	switch {
+	case strings.HasPrefix(payload.Event, "recurring."):
+		return t.subscriptionService.HandleWebhook(tenantID, payload.Event, payload.Data)
+	case strings.HasPrefix(payload.Event, "payout."):
+		if err := t.payoutService.HandleWebhook(tenantID, payload.Event, payload.Data); err != nil {
+			return err
+		}
+		return t.ledgerService.HandleWebhook(tenantID, payload.Event, payload.Data)
+	case strings.HasPrefix(payload.Event, "refund."):
+		return t.ledgerService.HandleWebhook(tenantID, payload.Event, payload.Data)
+	}
+	return nil
+}
+
+func (t *webhookController) recordInvoice(tenantID string, rawBody []byte) error {
+	if err := t.invoiceService.HandleCallback(tenantID, rawBody); err != nil {
+		return err
+	}
+	if err := t.feeService.HandleInvoiceCallback(tenantID, rawBody); err != nil {
+		return err
+	}
+	return t.ledgerService.HandleInvoiceCallback(tenantID, rawBody)
+}
+
+// handleAccountEvent keeps the tenant's sub-account status in sync with xenPlatform.
+// Account events carry no reference_id, so they are matched by account ID instead.
+func (t *webhookController) handleAccountEvent(c *fiber.Ctx, rawBody []byte) error {
+	var payload dto.XenditAccountWebhook
+	if err := json.Unmarshal(rawBody, &payload); err != nil || payload.Data.ID == "" {
+		t.logger.Error("Failed to parse account webhook", zap.ByteString("body", rawBody), zap.Error(err))
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid account webhook body",
+		})
+	}
+
+	updated, err := t.tenantService.UpdateAccountStatus(payload.Data.ID, payload.Data.Status)
+	if err != nil {
+		t.logger.Error("tenantService.UpdateAccountStatus", zap.String("account_id", payload.Data.ID), zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to update account status",
+		})
+	}
+
+	// An account the broker doesn't know will never match, so it is
+	// acknowledged rather than retried by Xendit.
+	if !updated {
+		t.logger.Warn("Unknown account in webhook", zap.String("event", payload.Event), zap.String("account_id", payload.Data.ID))
+		return c.JSON(fiber.Map{
+			"status": "ignored",
+		})
+	}
+
+	t.logger.Info("Account status updated", zap.String("event", payload.Event),
+		zap.String("account_id", payload.Data.ID), zap.String("status", payload.Data.Status))
+	return c.JSON(fiber.Map{
+		"status": "updated",
+	})
 }
@@ -0,0 +1,284 @@
+package controller
+
+import (
+	"fmt"
+	"payment-broker/internal/model/dto"
+	"payment-broker/internal/service"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type WebhookDeliveryController interface {
+	ListWebhookDeliveries(c *fiber.Ctx) error
+	ListTenantWebhookDeliveries(c *fiber.Ctx) error
+	SendTestWebhook(c *fiber.Ctx) error
+	GetWebhookBreakers(c *fiber.Ctx) error
+	SkipQueuedWebhook(c *fiber.Ctx) error
+	ResumeQueuedWebhook(c *fiber.Ctx) error
+}
+
+type webhookDeliveryController struct {
+	logger                 *zap.Logger
+	webhookDeliveryService service.WebhookDeliveryService
+	webhookPingService     service.WebhookPingService
+	webhookBreakerService  service.WebhookBreakerService
+}
+
+func NewWebhookDeliveryController(logger *zap.Logger, webhookDeliveryService service.WebhookDeliveryService,
+	webhookPingService service.WebhookPingService, webhookBreakerService service.WebhookBreakerService) WebhookDeliveryController {
+	return &webhookDeliveryController{
+		logger:                 logger,
+		webhookDeliveryService: webhookDeliveryService,
+		webhookPingService:     webhookPingService,
+		webhookBreakerService:  webhookBreakerService,
+	}
+}
+
+// ListWebhookDeliveries godoc
+// @Summary      List Webhook Deliveries
+// @Description  The calling tenant's webhook delivery attempts, newest first, with request headers and body, response status and excerpt, latency and error. The API key header is masked.
+// @Tags         action
+// @Produce      json
+// @Param        X-Api-Key   header    string                  true   "API Key"
+// @Param        event_type  query     string                  false  "Only this event type, e.g. invoice.paid"
+// @Param        status      query     string                  false  "succeeded, failed or an HTTP status code"
+// @Param        from        query     string                  false  "Only attempts at or after this RFC3339 time"
+// @Param        to          query     string                  false  "Only attempts at or before this RFC3339 time"
+// @Param        limit       query     int                     false  "Number of attempts, 50 by default"
+// @Param        before_id   query     int                     false  "Only attempts with a lower ID, for the next page"
+// @Success      200         {array}   map[string]interface{}  "Webhook deliveries"
+// @Failure      400         {object}  map[string]interface{}  "Invalid query parameter"
+// @Router       /xendit/action/webhook-deliveries [get]
+func (t *webhookDeliveryController) ListWebhookDeliveries(c *fiber.Ctx) error {
+	tenantID := c.Locals("X-Tenant-ID").(string)
+
+	filter, err := parseWebhookDeliveryFilter(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	deliveries, err := t.webhookDeliveryService.GetTenantDeliveries(tenantID, filter)
+	if err != nil {
+		t.logger.Error("webhookDeliveryService.GetTenantDeliveries", zap.String("tenant_id", tenantID), zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get webhook deliveries",
+		})
+	}
+
+	return c.JSON(deliveries)
+}
+
+// ListTenantWebhookDeliveries godoc
+// @Summary      List Tenant Webhook Deliveries
+// @Description  A tenant's webhook delivery attempts, newest first, filtered like the tenant's own listing
+// @Tags         admin
+// @Produce      json
+// @Param        X-Admin-Key  header    string                  true   "Admin Key"
+// @Param        id           path      int                     true   "Tenant ID"
+// @Param        event_type   query     string                  false  "Only this event type, e.g. invoice.paid"
+// @Param        status       query     string                  false  "succeeded, failed or an HTTP status code"
+// @Param        from         query     string                  false  "Only attempts at or after this RFC3339 time"
+// @Param        to           query     string                  false  "Only attempts at or before this RFC3339 time"
+// @Param        limit        query     int                     false  "Number of attempts, 50 by default"
+// @Param        before_id    query     int                     false  "Only attempts with a lower ID, for the next page"
+// @Success      200          {array}   map[string]interface{}  "Webhook deliveries"
+// @Failure      400          {object}  map[string]interface{}  "Invalid tenant ID or query parameter"
+// @Failure      500          {object}  map[string]interface{}  "Failed to get webhook deliveries"
+// @Router       /admin/tenants/{id}/webhook-deliveries [get]
+func (t *webhookDeliveryController) ListTenantWebhookDeliveries(c *fiber.Ctx) error {
+	tenantID, err := c.ParamsInt("id")
+	if err != nil || tenantID <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid tenant ID",
+		})
+	}
+
+	filter, err := parseWebhookDeliveryFilter(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	deliveries, err := t.webhookDeliveryService.GetDeliveries(uint(tenantID), filter)
+	if err != nil {
+		t.logger.Error("webhookDeliveryService.GetDeliveries", zap.Int("tenant_id", tenantID), zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get webhook deliveries",
+		})
+	}
+
+	return c.JSON(deliveries)
+}
+
+// SendTestWebhook godoc
+// @Summary      Send Test Webhook
+// @Description  Deliver a sample event (invoice.paid, payout.succeeded, payout.failed, recurring.cycle.succeeded or refund.succeeded) to the tenant's subscribed endpoints through the normal delivery path, and return each endpoint's response. The broker's own records are not changed.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        X-Admin-Key  header    string                  true  "Admin Key"
+// @Param        id           path      int                     true  "Tenant ID"
+// @Param        body         body      dto.WebhookTestRequest  true  "Sample event type"
+// @Success      200          {array}   map[string]interface{}  "Delivery attempts, including failed ones"
+// @Failure      400          {object}  map[string]interface{}  "Invalid tenant ID or request body"
+// @Failure      422          {object}  map[string]interface{}  "No endpoint subscribed to the event type, or validation failed"
+// @Router       /admin/tenants/{id}/webhook-test [post]
+func (t *webhookDeliveryController) SendTestWebhook(c *fiber.Ctx) error {
+	tenantID, err := c.ParamsInt("id")
+	if err != nil || tenantID <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid tenant ID",
+		})
+	}
+
+	var body dto.WebhookTestRequest
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if errs := body.Validate(); len(errs) > 0 {
+		return sendValidationErrors(c, errs)
+	}
+
+	deliveries, err := t.webhookPingService.SendTestEvent(c.Context(), uint(tenantID), body.EventType)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(deliveries)
+}
+
+// GetWebhookBreakers godoc
+// @Summary      Get Webhook Breakers
+// @Description  The circuit breakers of a tenant's webhook URLs that have failed at least once: state (CLOSED, OPEN or HALF_OPEN while being probed), consecutive failures, last error, next probe time, the number of deliveries queued behind it, and the failed ordered deliveries to the URL that hold back their resource, with attempts, last error and when they were dead-lettered
+// @Tags         admin
+// @Produce      json
+// @Param        X-Admin-Key  header    string                  true  "Admin Key"
+// @Param        id           path      int                     true  "Tenant ID"
+// @Success      200          {array}   map[string]interface{}  "Webhook breakers"
+// @Failure      400          {object}  map[string]interface{}  "Invalid tenant ID"
+// @Failure      500          {object}  map[string]interface{}  "Failed to get webhook breakers"
+// @Router       /admin/tenants/{id}/webhook-breakers [get]
+func (t *webhookDeliveryController) GetWebhookBreakers(c *fiber.Ctx) error {
+	tenantID, err := c.ParamsInt("id")
+	if err != nil || tenantID <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid tenant ID",
+		})
+	}
+
+	breakers, err := t.webhookBreakerService.GetBreakers(uint(tenantID))
+	if err != nil {
+		t.logger.Error("webhookBreakerService.GetBreakers", zap.Int("tenant_id", tenantID), zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get webhook breakers",
+		})
+	}
+
+	return c.JSON(breakers)
+}
+
+// SkipQueuedWebhook godoc
+// @Summary      Skip Stuck Webhook
+// @Description  Drop a failed ordered delivery so the next delivery of its resource is sent. The skipped event is not delivered again.
+// @Tags         admin
+// @Produce      json
+// @Param        X-Admin-Key  header    string                  true  "Admin Key"
+// @Param        id           path      int                     true  "Tenant ID"
+// @Param        queueID      path      int                     true  "Stuck delivery ID from the webhook breakers"
+// @Success      200          {object}  map[string]interface{}  "Delivery skipped"
+// @Failure      400          {object}  map[string]interface{}  "Invalid tenant or delivery ID"
+// @Failure      404          {object}  map[string]interface{}  "No failed ordered delivery with this ID"
+// @Router       /admin/tenants/{id}/webhook-queue/{queueID}/skip [post]
+func (t *webhookDeliveryController) SkipQueuedWebhook(c *fiber.Ctx) error {
+	return t.handleQueuedWebhook(c, "skipped", t.webhookBreakerService.SkipOrdered)
+}
+
+// ResumeQueuedWebhook godoc
+// @Summary      Retry Stuck Webhook
+// @Description  Send a failed or dead-lettered ordered delivery again now, with a fresh set of attempts
+// @Tags         admin
+// @Produce      json
+// @Param        X-Admin-Key  header    string                  true  "Admin Key"
+// @Param        id           path      int                     true  "Tenant ID"
+// @Param        queueID      path      int                     true  "Stuck delivery ID from the webhook breakers"
+// @Success      200          {object}  map[string]interface{}  "Delivery resumed"
+// @Failure      400          {object}  map[string]interface{}  "Invalid tenant or delivery ID"
+// @Failure      404          {object}  map[string]interface{}  "No failed ordered delivery with this ID"
+// @Router       /admin/tenants/{id}/webhook-queue/{queueID}/retry [post]
+func (t *webhookDeliveryController) ResumeQueuedWebhook(c *fiber.Ctx) error {
+	return t.handleQueuedWebhook(c, "resumed", t.webhookBreakerService.ResumeOrdered)
+}
+
+func (t *webhookDeliveryController) handleQueuedWebhook(c *fiber.Ctx, status string, action func(tenantID uint, id uint, admin string) error) error {
+	tenantID, err := c.ParamsInt("id")
+	if err != nil || tenantID <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid tenant ID",
+		})
+	}
+
+	queueID, err := c.ParamsInt("queueID")
+	if err != nil || queueID <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid delivery ID",
+		})
+	}
+
+	if err := action(uint(tenantID), uint(queueID), adminUser(c)); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"id":     queueID,
+		"status": status,
+	})
+}
+
+func parseWebhookDeliveryFilter(c *fiber.Ctx) (dto.WebhookDeliveryFilter, error) {
+	filter := dto.WebhookDeliveryFilter{
+		EventType: c.Query("event_type"),
+		Status:    c.Query("status"),
+		Limit:     c.QueryInt("limit", 50),
+	}
+
+	if filter.Status != "" && filter.Status != "succeeded" && filter.Status != "failed" {
+		if code, err := strconv.Atoi(filter.Status); err != nil || code < 100 || code > 599 {
+			return filter, fmt.Errorf("status must be succeeded, failed or an HTTP status code")
+		}
+	}
+
+	for key, value := range map[string]*time.Time{"from": &filter.From, "to": &filter.To} {
+		if raw := c.Query(key); raw != "" {
+			parsed, err := time.Parse(time.RFC3339, raw)
+			if err != nil {
+				return filter, fmt.Errorf("%s must be an RFC3339 timestamp", key)
+			}
+			*value = parsed
+		}
+	}
+
+	if filter.Limit < 1 || filter.Limit > 200 {
+		return filter, fmt.Errorf("limit must be between 1 and 200")
+	}
+
+	beforeID := c.QueryInt("before_id", 0)
+	if beforeID < 0 {
+		return filter, fmt.Errorf("before_id must not be negative")
+	}
+	filter.BeforeID = uint(beforeID)
+
+	return filter, nil
+}
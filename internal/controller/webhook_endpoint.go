@@ -0,0 +1,180 @@
+package controller
+
+import (
+	"payment-broker/internal/helper"
+	"payment-broker/internal/model/dto"
+	"payment-broker/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type WebhookEndpointController interface {
+	RegisterWebhookEndpoint(c *fiber.Ctx) error
+	GetWebhookEndpoints(c *fiber.Ctx) error
+	UpdateWebhookEndpoint(c *fiber.Ctx) error
+	DeleteWebhookEndpoint(c *fiber.Ctx) error
+}
+
+type webhookEndpointController struct {
+	logger                 *zap.Logger
+	webhookEndpointService service.WebhookEndpointService
+	egress                 *helper.EgressPolicy
+}
+
+func NewWebhookEndpointController(logger *zap.Logger, webhookEndpointService service.WebhookEndpointService,
+	egress *helper.EgressPolicy) WebhookEndpointController {
+	return &webhookEndpointController{
+		logger:                 logger,
+		webhookEndpointService: webhookEndpointService,
+		egress:                 egress,
+	}
+}
+
+// RegisterWebhookEndpoint godoc
+// @Summary      Register Webhook Endpoint
+// @Description  Add a URL that receives the events it subscribes to, by exact type (payout.failed), prefix pattern (invoice.*) or *. The URL must not point to a private, loopback or link-local address, and must use https:// where the broker requires it. Once a tenant has an endpoint, its onboarding webhook URL no longer receives events. Format raw (the default) forwards Xendit's payload unchanged, and envelope.v1 wraps it in a CloudEvents-style envelope with id, type, tenantid, time, source and the original payload in data. An ordered endpoint gets the events of each invoice, payout or other resource one at a time in the order they arrived, retrying a failed one before sending the next.
+// @Tags         action
+// @Accept       json
+// @Produce      json
+// @Param        X-Api-Key  header    string                      true  "API Key"
+// @Param        body       body      dto.WebhookEndpointRequest  true  "Webhook endpoint payload"
+// @Success      201        {object}  map[string]interface{}      "Webhook endpoint registered"
+// @Failure      400        {object}  map[string]interface{}      "Invalid request body"
+// @Failure      422        {object}  map[string]interface{}      "Validation failed"
+// @Router       /xendit/action/webhook-endpoints [post]
+func (t *webhookEndpointController) RegisterWebhookEndpoint(c *fiber.Ctx) error {
+	tenantID := c.Locals("X-Tenant-ID").(string)
+
+	var body dto.WebhookEndpointRequest
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if errs := t.validate(c, &body); len(errs) > 0 {
+		return sendValidationErrors(c, errs)
+	}
+
+	endpoint, err := t.webhookEndpointService.Register(tenantID, &body)
+	if err != nil {
+		t.logger.Error("webhookEndpointService.Register", zap.String("tenant_id", tenantID), zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to register webhook endpoint",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(endpoint)
+}
+
+// GetWebhookEndpoints godoc
+// @Summary      List Webhook Endpoints
+// @Description  List the tenant's webhook endpoints and their event subscriptions
+// @Tags         action
+// @Produce      json
+// @Param        X-Api-Key  header    string                  true  "API Key"
+// @Success      200        {array}   map[string]interface{}  "Webhook endpoints"
+// @Failure      500        {object}  map[string]interface{}  "Failed to get webhook endpoints"
+// @Router       /xendit/action/webhook-endpoints [get]
+func (t *webhookEndpointController) GetWebhookEndpoints(c *fiber.Ctx) error {
+	tenantID := c.Locals("X-Tenant-ID").(string)
+
+	endpoints, err := t.webhookEndpointService.GetTenantEndpoints(tenantID)
+	if err != nil {
+		t.logger.Error("webhookEndpointService.GetTenantEndpoints", zap.String("tenant_id", tenantID), zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get webhook endpoints",
+		})
+	}
+
+	return c.JSON(endpoints)
+}
+
+// UpdateWebhookEndpoint godoc
+// @Summary      Update Webhook Endpoint
+// @Description  Replace an endpoint's URL and event types, and optionally change its format or ordering or activate or deactivate it
+// @Tags         action
+// @Accept       json
+// @Produce      json
+// @Param        X-Api-Key  header    string                      true  "API Key"
+// @Param        id         path      int                         true  "Webhook endpoint ID"
+// @Param        body       body      dto.WebhookEndpointRequest  true  "Webhook endpoint payload"
+// @Success      200        {object}  map[string]interface{}      "Webhook endpoint updated"
+// @Failure      404        {object}  map[string]interface{}      "Webhook endpoint not found"
+// @Failure      422        {object}  map[string]interface{}      "Validation failed"
+// @Router       /xendit/action/webhook-endpoints/{id} [put]
+func (t *webhookEndpointController) UpdateWebhookEndpoint(c *fiber.Ctx) error {
+	tenantID := c.Locals("X-Tenant-ID").(string)
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid webhook endpoint ID",
+		})
+	}
+
+	var body dto.WebhookEndpointRequest
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if errs := t.validate(c, &body); len(errs) > 0 {
+		return sendValidationErrors(c, errs)
+	}
+
+	endpoint, err := t.webhookEndpointService.UpdateTenantEndpoint(tenantID, uint(id), &body)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Webhook endpoint not found",
+		})
+	}
+
+	return c.JSON(endpoint)
+}
+
+// DeleteWebhookEndpoint godoc
+// @Summary      Delete Webhook Endpoint
+// @Description  Remove one of the tenant's webhook endpoints
+// @Tags         action
+// @Produce      json
+// @Param        X-Api-Key  header    string                  true  "API Key"
+// @Param        id         path      int                     true  "Webhook endpoint ID"
+// @Success      200        {object}  map[string]interface{}  "Webhook endpoint deleted"
+// @Failure      404        {object}  map[string]interface{}  "Webhook endpoint not found"
+// @Router       /xendit/action/webhook-endpoints/{id} [delete]
+func (t *webhookEndpointController) DeleteWebhookEndpoint(c *fiber.Ctx) error {
+	tenantID := c.Locals("X-Tenant-ID").(string)
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid webhook endpoint ID",
+		})
+	}
+
+	if err := t.webhookEndpointService.DeleteTenantEndpoint(tenantID, uint(id)); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Webhook endpoint not found",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Webhook endpoint deleted successfully",
+	})
+}
+
+// validate checks the body, then the URL against the egress policy, which
+// needs a DNS lookup and so runs only once the URL is well formed.
+func (t *webhookEndpointController) validate(c *fiber.Ctx, body *dto.WebhookEndpointRequest) dto.ValidationErrors {
+	if errs := body.Validate(); len(errs) > 0 {
+		return errs
+	}
+
+	if err := t.egress.CheckURL(c.Context(), body.URL); err != nil {
+		return dto.ValidationErrors{{Field: "url", Message: err.Error()}}
+	}
+	return nil
+}
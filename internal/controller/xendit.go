@@ -2,8 +2,17 @@ package controller
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
+	"payment-broker/internal/helper"
+	model "payment-broker/internal/model/db"
+	"payment-broker/internal/model/dto"
 	"payment-broker/internal/service"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
@@ -14,48 +23,162 @@ type XenditController interface {
 	CreateSubscription(c *fiber.Ctx) error
 	CreatePayout(c *fiber.Ctx) error
 	CreateCustomer(c *fiber.Ctx) error
+	GetBalance(c *fiber.Ctx) error
+	GetTransactions(c *fiber.Ctx) error
 }
 
 type XenditLibController struct {
-	logger        *zap.Logger
-	xenditService service.XenditService
+	logger                *zap.Logger
+	xenditService         service.XenditService
+	tenantService         service.TenantService
+	splitRuleService      service.SplitRuleService
+	invoiceService        service.InvoiceService
+	subscriptionService   service.SubscriptionService
+	payoutService         service.PayoutService
+	payoutLimitService    service.PayoutLimitService
+	payoutApprovalService service.PayoutApprovalService
+	beneficiaryService    service.BeneficiaryService
 }
 
-func NewXenditController(logger *zap.Logger, xenditService service.XenditService) XenditController {
+func NewXenditController(logger *zap.Logger, xenditService service.XenditService, tenantService service.TenantService, splitRuleService service.SplitRuleService,
+	invoiceService service.InvoiceService, subscriptionService service.SubscriptionService, payoutService service.PayoutService, payoutLimitService service.PayoutLimitService,
+	payoutApprovalService service.PayoutApprovalService, beneficiaryService service.BeneficiaryService) XenditController {
 	return &XenditLibController{
-		logger:        logger,
-		xenditService: xenditService,
+		logger:                logger,
+		xenditService:         xenditService,
+		tenantService:         tenantService,
+		splitRuleService:      splitRuleService,
+		invoiceService:        invoiceService,
+		subscriptionService:   subscriptionService,
+		payoutService:         payoutService,
+		payoutLimitService:    payoutLimitService,
+		payoutApprovalService: payoutApprovalService,
+		beneficiaryService:    beneficiaryService,
 	}
 }
 
-func (t *XenditLibController) handleXenditRequest(c *fiber.Ctx, endpoint string, errorMsg string) error {
-	ctx := c.Context()
+// responseRecorder lets an endpoint keep broker-side state from Xendit's response
+// before it is forwarded to the tenant.
+type responseRecorder func(tenantID string, resp *dto.XenditResponse)
+
+func (t *XenditLibController) handleXenditRequest(c *fiber.Ctx, endpoint string, request dto.ActionRequest, errorMsg string, record responseRecorder) error {
+	data, ok := t.parseActionRequest(c, endpoint, request)
+	if !ok {
+		return nil
+	}
+
+	resp, err := t.sendActionRequest(c, endpoint, data)
+	if err != nil {
+		t.logger.Error("Xendit API error", zap.Error(err))
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
+			"error": errorMsg,
+		})
+	}
+
+	if record != nil {
+		record(c.Locals("X-Tenant-ID").(string), resp)
+	}
+
+	return sendXenditResponse(c, resp)
+}
+
+// parseActionRequest validates the body against its typed request and returns it
+// with the tenant prefix applied. When it returns false the error response has
+// already been written.
+func (t *XenditLibController) parseActionRequest(c *fiber.Ctx, endpoint string, request dto.ActionRequest) (map[string]interface{}, bool) {
 	rawBody := c.Body()
+	tenantID := c.Locals("X-Tenant-ID").(string)
 
-	accountID := c.Locals("X-Account-ID").(string)
-	tenantID := c.Locals("X-Tenant-ID")
+	typeErrs, err := decodeFields(rawBody, request)
+	if err != nil {
+		t.logger.Error("Failed to parse request body", zap.Error(err))
+		c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+		return nil, false
+	}
+
+	// The tenant's currencies are applied before Validate so the amount is
+	// checked against the currency that is actually forwarded.
+	var currency string
+	if currencyRequest, ok := request.(dto.CurrencyRequest); ok {
+		currencies, err := t.getTenantCurrencies(tenantID)
+		if err != nil {
+			t.logger.Error("tenantService.GetCurrencies", zap.String("tenant_id", tenantID), zap.Error(err))
+			c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to load tenant currencies",
+			})
+			return nil, false
+		}
+		currency = currencyRequest.ApplyTenantCurrencies(currencies.AllowedCurrencies, currencies.DefaultCurrency)
+	}
+
+	// A field of the wrong type is left empty, so Validate's own complaint
+	// about it is dropped in favour of the type error.
+	errs := typeErrs
+	for _, fieldErr := range request.Validate() {
+		if !typeErrs.Has(fieldErr.Field) {
+			errs = append(errs, fieldErr)
+		}
+	}
+	if len(errs) > 0 {
+		sendValidationErrors(c, errs)
+		return nil, false
+	}
 
+	// The typed request only covers the fields the broker checks, so the raw
+	// body is forwarded to keep every optional Xendit field the tenant sent.
 	var data map[string]interface{}
 	if err := json.Unmarshal(rawBody, &data); err != nil {
 		t.logger.Error("Failed to parse request body", zap.Error(err))
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": "Invalid request body",
 		})
+		return nil, false
+	}
+
+	if currency != "" {
+		data["currency"] = currency
 	}
 
 	switch endpoint {
 	case "/v2/invoices":
 		if externalID, ok := data["external_id"].(string); ok && externalID != "" {
-			data["external_id"] = fmt.Sprintf("%s:%s", tenantID, externalID)
+			data["external_id"] = helper.TenantReference(tenantID, externalID)
 		}
 	default:
 		if referenceID, ok := data["reference_id"].(string); ok && referenceID != "" {
-			data["reference_id"] = fmt.Sprintf("%s:%s", tenantID, referenceID)
+			data["reference_id"] = helper.TenantReference(tenantID, referenceID)
 		}
 	}
 
-	resp, err := t.xenditService.CreateRequest(ctx, data, endpoint, accountID)
+	return data, true
+}
+
+func (t *XenditLibController) getTenantCurrencies(tenantID string) (*dto.TenantCurrencies, error) {
+	id, err := helper.ParseTenantID(tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return t.tenantService.GetCurrencies(id)
+}
+
+func (t *XenditLibController) sendActionRequest(c *fiber.Ctx, endpoint string, data map[string]interface{}) (*dto.XenditResponse, error) {
+	accountID := c.Locals("X-Account-ID").(string)
+	tenantID := c.Locals("X-Tenant-ID").(string)
+
+	splitRuleID := t.splitRuleService.ResolveSplitRule(tenantID, endpoint)
+
+	return t.xenditService.CreateRequest(c.Context(), data, endpoint, accountID, splitRuleID)
+}
 
+// handleXenditQuery forwards a read-only request for the tenant's own sub-account.
+// Only the given query parameters are passed through, and the for-user-id always
+// comes from the API key, so a tenant can't reach another account.
+func (t *XenditLibController) handleXenditQuery(c *fiber.Ctx, endpoint string, query url.Values, errorMsg string) error {
+	accountID := c.Locals("X-Account-ID").(string)
+
+	resp, err := t.xenditService.GetRequest(c.Context(), endpoint, query, accountID)
 	if err != nil {
 		t.logger.Error("Xendit API error", zap.Error(err))
 		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
@@ -63,6 +186,96 @@ func (t *XenditLibController) handleXenditRequest(c *fiber.Ctx, endpoint string,
 		})
 	}
 
+	return sendXenditResponse(c, resp)
+}
+
+// decodeFields decodes a JSON object body into the struct request points to,
+// one field at a time, so every field holding a value of the wrong type is
+// reported instead of only the first. The other fields are still decoded. The
+// error is for a body that is not a JSON object.
+func decodeFields(body []byte, request interface{}) (dto.ValidationErrors, error) {
+	var errs dto.ValidationErrors
+	if err := decodeStruct(body, reflect.ValueOf(request).Elem(), "", &errs); err != nil {
+		return nil, err
+	}
+	return errs, nil
+}
+
+func decodeStruct(raw json.RawMessage, target reflect.Value, prefix string, errs *dto.ValidationErrors) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return err
+	}
+
+	targetType := target.Type()
+	for i := 0; i < targetType.NumField(); i++ {
+		field := targetType.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if !field.IsExported() || name == "" || name == "-" {
+			continue
+		}
+
+		value, ok := fields[name]
+		if !ok || string(value) == "null" {
+			continue
+		}
+
+		path := prefix + name
+		fieldValue := target.Field(i)
+
+		structType := field.Type
+		if structType.Kind() == reflect.Ptr {
+			structType = structType.Elem()
+		}
+		if structType.Kind() == reflect.Struct {
+			nested := reflect.New(structType)
+			if err := decodeStruct(value, nested.Elem(), path+".", errs); err != nil {
+				errs.Add(path, "must be of type object")
+				continue
+			}
+			if field.Type.Kind() == reflect.Ptr {
+				fieldValue.Set(nested)
+			} else {
+				fieldValue.Set(nested.Elem())
+			}
+			continue
+		}
+
+		if err := json.Unmarshal(value, fieldValue.Addr().Interface()); err != nil {
+			var typeErr *json.UnmarshalTypeError
+			if !errors.As(err, &typeErr) {
+				return err
+			}
+			if typeErr.Field != "" {
+				path += "." + typeErr.Field
+			}
+			errs.Add(path, fmt.Sprintf("must be of type %s", jsonTypeName(typeErr.Type)))
+		}
+	}
+	return nil
+}
+
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Float32, reflect.Float64, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Struct, reflect.Map, reflect.Ptr:
+		return "object"
+	}
+	return t.Kind().String()
+}
+
+func sendValidationErrors(c *fiber.Ctx, errs dto.ValidationErrors) error {
+	return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+		"error":  "Validation failed",
+		"fields": errs,
+	})
+}
+
+func sendXenditResponse(c *fiber.Ctx, resp *dto.XenditResponse) error {
 	for key, values := range resp.Header {
 		for _, value := range values {
 			c.Set(key, value)
@@ -79,13 +292,14 @@ func (t *XenditLibController) handleXenditRequest(c *fiber.Ctx, endpoint string,
 // @Accept       json
 // @Produce      json
 // @Param        X-Api-Key  header    string                   true  "API Key"
-// @Param        body          body      map[string]interface{}  true  "Payment invoice payload"
+// @Param        body          body      dto.InvoiceRequest      true  "Payment invoice payload"
 // @Success      200           {object}  map[string]interface{}  "Payment invoice created successfully"
 // @Failure      400           {object}  map[string]interface{}  "Invalid request body"
+// @Failure      422           {object}  map[string]interface{}  "Validation failed"
 // @Failure      502           {object}  map[string]interface{}  "Failed to process payment"
 // @Router       /xendit/action/invoices [post]
 func (t *XenditLibController) CreatePayment(c *fiber.Ctx) error {
-	return t.handleXenditRequest(c, "/v2/invoices", "Failed to process payment")
+	return t.handleXenditRequest(c, "/v2/invoices", &dto.InvoiceRequest{}, "Failed to process payment", t.invoiceService.RecordInvoice)
 }
 
 // CreateSubscription godoc
@@ -95,29 +309,140 @@ func (t *XenditLibController) CreatePayment(c *fiber.Ctx) error {
 // @Accept       json
 // @Produce      json
 // @Param        X-Api-Key  header    string                   true  "API Key"
-// @Param        body          body      map[string]interface{}  true  "Subscription plan payload"
+// @Param        body          body      dto.PlanRequest         true  "Subscription plan payload"
 // @Success      200           {object}  map[string]interface{}  "Subscription plan created successfully"
 // @Failure      400           {object}  map[string]interface{}  "Invalid request body"
+// @Failure      422           {object}  map[string]interface{}  "Validation failed"
 // @Failure      502           {object}  map[string]interface{}  "Failed to process subscription"
 // @Router       /xendit/action/recurring/plans [post]
 func (t *XenditLibController) CreateSubscription(c *fiber.Ctx) error {
-	return t.handleXenditRequest(c, "/recurring/plans", "Failed to process subscription")
+	return t.handleXenditRequest(c, "/recurring/plans", &dto.PlanRequest{}, "Failed to process subscription", t.subscriptionService.RecordPlan)
 }
 
 // CreatePayout godoc
 // @Summary      Create Payout
-// @Description  Create a new payout transaction via Xendit
+// @Description  Create a new payout transaction via Xendit. Payouts at or above the tenant's approval threshold, or to destinations that aren't verified beneficiaries under the same holder name, are held as PENDING_APPROVAL unless the tenant's beneficiary policy is REJECT.
 // @Tags         action
 // @Accept       json
 // @Produce      json
 // @Param        X-Api-Key  header    string                   true  "API Key"
-// @Param        body          body      map[string]interface{}  true  "Payout payload"
+// @Param        X-Requested-By  header  string                   false  "Name of the person requesting the payout, kept in the approval history as unverified"
+// @Param        body          body      dto.PayoutRequest       true  "Payout payload"
 // @Success      200           {object}  map[string]interface{}  "Payout created successfully"
+// @Success      202           {object}  map[string]interface{}  "Payout held for approval"
 // @Failure      400           {object}  map[string]interface{}  "Invalid request body"
+// @Failure      403           {object}  map[string]interface{}  "Payout blocked by a tenant limit or unverified beneficiary"
+// @Failure      422           {object}  map[string]interface{}  "Validation failed"
 // @Failure      502           {object}  map[string]interface{}  "Failed to process payout"
 // @Router       /xendit/action/payouts [post]
 func (t *XenditLibController) CreatePayout(c *fiber.Ctx) error {
-	return t.handleXenditRequest(c, "/v2/payouts", "Failed to process payout")
+	request := &dto.PayoutRequest{}
+	data, ok := t.parseActionRequest(c, "/v2/payouts", request)
+	if !ok {
+		return nil
+	}
+
+	tenantID := c.Locals("X-Tenant-ID").(string)
+	id, err := helper.ParseTenantID(tenantID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to process payout",
+		})
+	}
+
+	policy, err := t.beneficiaryService.CheckDestination(id, request)
+	if err != nil {
+		t.logger.Error("beneficiaryService.CheckDestination", zap.String("tenant_id", tenantID), zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to process payout",
+		})
+	}
+
+	if policy == model.BeneficiaryPolicyReject {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error":      "Payout destination is not a verified beneficiary",
+			"error_code": "PAYOUT_BENEFICIARY_NOT_VERIFIED",
+		})
+	}
+
+	// Limits that approval can't change are applied before the payout is held,
+	// so reviewers never see one that would fail anyway.
+	if err := t.payoutLimitService.Check(id, request.Amount, request.Currency, request.ChannelCode); err != nil {
+		return t.sendPayoutLimitError(c, tenantID, err)
+	}
+
+	requiresApproval, err := t.payoutApprovalService.RequiresApproval(id, request.Amount, request.Currency)
+	if err != nil {
+		t.logger.Error("payoutApprovalService.RequiresApproval", zap.String("tenant_id", tenantID), zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to process payout",
+		})
+	}
+
+	var note string
+	if policy == model.BeneficiaryPolicyApproval {
+		requiresApproval = true
+		note = "destination is not a verified beneficiary under this holder name"
+	}
+
+	if requiresApproval {
+		// The requester is the tenant the API key belongs to. X-Requested-By
+		// is the tenant's own claim, so it is only noted.
+		if requester := c.Get("X-Requested-By"); requester != "" {
+			if note != "" {
+				note += "; "
+			}
+			note += "requested by " + requester + " (unverified)"
+		}
+
+		approval, err := t.payoutApprovalService.Submit(c.Context(), id, "tenant:"+tenantID, note, request, data)
+		if err != nil {
+			t.logger.Error("payoutApprovalService.Submit", zap.String("tenant_id", tenantID), zap.Error(err))
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to process payout",
+			})
+		}
+
+		return c.Status(fiber.StatusAccepted).JSON(approval)
+	}
+
+	reservation, err := t.payoutLimitService.Reserve(c.Context(), id, request.Amount, request.Currency, request.ChannelCode)
+	if err != nil {
+		return t.sendPayoutLimitError(c, tenantID, err)
+	}
+
+	resp, err := t.sendActionRequest(c, "/v2/payouts", data)
+	if err != nil {
+		t.payoutLimitService.Release(c.Context(), reservation)
+		t.logger.Error("Xendit API error", zap.Error(err))
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
+			"error": "Failed to process payout",
+		})
+	}
+
+	if resp.StatusCode < fiber.StatusOK || resp.StatusCode >= fiber.StatusMultipleChoices {
+		t.payoutLimitService.Release(c.Context(), reservation)
+	}
+
+	t.payoutService.RecordPayout(tenantID, resp)
+	return sendXenditResponse(c, resp)
+}
+
+// sendPayoutLimitError answers 403 when a tenant limit blocked the payout and
+// 500 when the limits couldn't be checked.
+func (t *XenditLibController) sendPayoutLimitError(c *fiber.Ctx, tenantID string, err error) error {
+	var limitErr *service.PayoutLimitError
+	if errors.As(err, &limitErr) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error":      limitErr.Message,
+			"error_code": limitErr.Code,
+		})
+	}
+
+	t.logger.Error("payoutLimitService", zap.String("tenant_id", tenantID), zap.Error(err))
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": "Failed to process payout",
+	})
 }
 
 // CreateCustomer godoc
@@ -127,11 +452,112 @@ func (t *XenditLibController) CreatePayout(c *fiber.Ctx) error {
 // @Accept       json
 // @Produce      json
 // @Param        X-Api-Key  header    string                   true  "API Key"
-// @Param        body          body      map[string]interface{}  true  "Customer payload"
+// @Param        body          body      dto.CustomerRequest     true  "Customer payload"
 // @Success      200           {object}  map[string]interface{}  "Customer created successfully"
 // @Failure      400           {object}  map[string]interface{}  "Invalid request body"
+// @Failure      422           {object}  map[string]interface{}  "Validation failed"
 // @Failure      502           {object}  map[string]interface{}  "Failed to process customer"
 // @Router       /xendit/action/customers [post]
 func (t *XenditLibController) CreateCustomer(c *fiber.Ctx) error {
-	return t.handleXenditRequest(c, "/customers", "Failed to process customer")
+	return t.handleXenditRequest(c, "/customers", &dto.CustomerRequest{}, "Failed to process customer", nil)
+}
+
+// GetBalance godoc
+// @Summary      Get Balance
+// @Description  Get the tenant sub-account balance via Xendit
+// @Tags         action
+// @Produce      json
+// @Param        X-Api-Key     header    string                  true   "API Key"
+// @Param        account_type  query     string                  false  "CASH, HOLDING or TAX"
+// @Param        currency      query     string                  false  "Currency code"
+// @Success      200           {object}  map[string]interface{}  "Balance retrieved successfully"
+// @Failure      400           {object}  map[string]interface{}  "Invalid query parameter"
+// @Failure      502           {object}  map[string]interface{}  "Failed to get balance"
+// @Router       /xendit/action/balance [get]
+func (t *XenditLibController) GetBalance(c *fiber.Ctx) error {
+	query := url.Values{}
+
+	if accountType := c.Query("account_type"); accountType != "" {
+		switch accountType {
+		case "CASH", "HOLDING", "TAX":
+			query.Set("account_type", accountType)
+		default:
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "account_type must be CASH, HOLDING or TAX",
+			})
+		}
+	}
+
+	if currency := c.Query("currency"); currency != "" {
+		query.Set("currency", currency)
+	}
+
+	return t.handleXenditQuery(c, "/balance", query, "Failed to get balance")
+}
+
+// GetTransactions godoc
+// @Summary      List Transactions
+// @Description  List the tenant sub-account transactions via Xendit with cursor pagination
+// @Tags         action
+// @Produce      json
+// @Param        X-Api-Key     header    string                  true   "API Key"
+// @Param        types         query     string                  false  "Comma separated transaction types"
+// @Param        statuses      query     string                  false  "Comma separated transaction statuses"
+// @Param        currency      query     string                  false  "Currency code"
+// @Param        reference_id  query     string                  false  "Reference ID without the tenant prefix"
+// @Param        created[gte]  query     string                  false  "Created at or after (RFC3339)"
+// @Param        created[lte]  query     string                  false  "Created at or before (RFC3339)"
+// @Param        updated[gte]  query     string                  false  "Updated at or after (RFC3339)"
+// @Param        updated[lte]  query     string                  false  "Updated at or before (RFC3339)"
+// @Param        limit         query     int                     false  "Page size, 1 to 50"
+// @Param        after_id      query     string                  false  "Cursor for the next page"
+// @Param        before_id     query     string                  false  "Cursor for the previous page"
+// @Success      200           {object}  map[string]interface{}  "Transactions retrieved successfully"
+// @Failure      400           {object}  map[string]interface{}  "Invalid query parameter"
+// @Failure      502           {object}  map[string]interface{}  "Failed to get transactions"
+// @Router       /xendit/action/transactions [get]
+func (t *XenditLibController) GetTransactions(c *fiber.Ctx) error {
+	tenantID := c.Locals("X-Tenant-ID").(string)
+	query := url.Values{}
+
+	for _, key := range []string{"types", "statuses", "channel_categories", "currency", "after_id", "before_id"} {
+		if value := c.Query(key); value != "" {
+			query.Set(key, value)
+		}
+	}
+
+	if c.Query("after_id") != "" && c.Query("before_id") != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "after_id and before_id can't be used together",
+		})
+	}
+
+	if referenceID := c.Query("reference_id"); referenceID != "" {
+		query.Set("reference_id", helper.TenantReference(tenantID, referenceID))
+	}
+
+	for _, key := range []string{"created[gte]", "created[lte]", "updated[gte]", "updated[lte]"} {
+		value := c.Query(key)
+		if value == "" {
+			continue
+		}
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": fmt.Sprintf("%s must be an RFC3339 timestamp", key),
+			})
+		}
+		query.Set(key, value)
+	}
+
+	if limit := c.Query("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 1 || n > 50 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "limit must be between 1 and 50",
+			})
+		}
+		query.Set("limit", limit)
+	}
+
+	return t.handleXenditQuery(c, "/transactions", query, "Failed to get transactions")
 }
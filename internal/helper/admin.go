@@ -0,0 +1,30 @@
+package helper
+
+import (
+	"crypto/subtle"
+	"os"
+	"strings"
+)
+
+// AdminIdentity returns the admin that key belongs to in ADMIN_API_KEYS, a
+// comma separated list of name:key pairs with one key per admin. Names can't
+// contain a colon, so they never clash with tenant identities such as
+// "tenant:5".
+func AdminIdentity(key string) (string, bool) {
+	if key == "" {
+		return "", false
+	}
+
+	var identity string
+	for _, entry := range strings.Split(os.Getenv("ADMIN_API_KEYS"), ",") {
+		name, adminKey, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok || name == "" || adminKey == "" {
+			continue
+		}
+		// Every entry is compared so the time taken doesn't reveal which one matched.
+		if subtle.ConstantTimeCompare([]byte(key), []byte(adminKey)) == 1 && identity == "" {
+			identity = name
+		}
+	}
+	return identity, identity != ""
+}
@@ -0,0 +1,151 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// reservedNetworks are ranges Go's net.IP predicates don't cover but that
+// never belong to a tenant's public server.
+var reservedNetworks = mustParseCIDRs("0.0.0.0/8", "100.64.0.0/10", "192.0.0.0/24", "198.18.0.0/15", "240.0.0.0/4")
+
+// EgressPolicy decides which URLs the broker may call on a tenant's behalf.
+// Private, loopback, link-local and other internal addresses are refused
+// unless they fall in an allowed network.
+type EgressPolicy struct {
+	RequireHTTPS bool
+	Allowed      []*net.IPNet
+}
+
+// LoadEgressPolicy reads WEBHOOK_ALLOWED_CIDRS, a comma separated list of
+// networks that may be called even though they are internal, and
+// WEBHOOK_REQUIRE_HTTPS, which defaults to true when APP_ENV is production.
+func LoadEgressPolicy() (*EgressPolicy, error) {
+	policy := &EgressPolicy{RequireHTTPS: os.Getenv("APP_ENV") == "production"}
+
+	if value := os.Getenv("WEBHOOK_REQUIRE_HTTPS"); value != "" {
+		requireHTTPS, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid WEBHOOK_REQUIRE_HTTPS: %w", err)
+		}
+		policy.RequireHTTPS = requireHTTPS
+	}
+
+	for _, cidr := range strings.Split(os.Getenv("WEBHOOK_ALLOWED_CIDRS"), ",") {
+		if cidr = strings.TrimSpace(cidr); cidr == "" {
+			continue
+		}
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid network %q in WEBHOOK_ALLOWED_CIDRS: %w", cidr, err)
+		}
+		policy.Allowed = append(policy.Allowed, network)
+	}
+
+	return policy, nil
+}
+
+// CheckURL checks the URL's scheme and every address its host resolves to.
+// The addresses can change before the broker connects, so Transport checks
+// them again on every connection.
+func (p *EgressPolicy) CheckURL(ctx context.Context, raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return fmt.Errorf("must be a valid URL starting with http:// or https://")
+	}
+	if p.RequireHTTPS && u.Scheme != "https" {
+		return fmt.Errorf("must use https://")
+	}
+
+	host := u.Hostname()
+	if ip := net.ParseIP(host); ip != nil {
+		if err := p.CheckIP(ip); err != nil {
+			return fmt.Errorf("points to %w", err)
+		}
+		return nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return fmt.Errorf("host %s can't be resolved", host)
+	}
+	for _, addr := range addrs {
+		if err := p.CheckIP(addr.IP); err != nil {
+			return fmt.Errorf("host %s resolves to %w", host, err)
+		}
+	}
+	return nil
+}
+
+func (p *EgressPolicy) CheckIP(ip net.IP) error {
+	for _, network := range p.Allowed {
+		if network.Contains(ip) {
+			return nil
+		}
+	}
+
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsUnspecified() || ip.IsMulticast() || ip.IsInterfaceLocalMulticast() {
+		return fmt.Errorf("%s, which is an internal address", ip)
+	}
+	for _, network := range reservedNetworks {
+		if network.Contains(ip) {
+			return fmt.Errorf("%s, which is a reserved address", ip)
+		}
+	}
+	return nil
+}
+
+// Transport connects only to addresses the policy allows. The check runs on
+// the resolved address of each connection, so a host that resolves to a
+// public address at registration and an internal one later is still refused.
+// Proxies from the environment are ignored, as they would hide the address.
+func (p *EgressPolicy) Transport() *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   5 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil {
+				return fmt.Errorf("refusing to connect to %s", address)
+			}
+			if err := p.CheckIP(ip); err != nil {
+				return fmt.Errorf("refusing to connect to %w", err)
+			}
+			return nil
+		},
+	}
+
+	return &http.Transport{
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+}
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks[i] = network
+	}
+	return networks
+}
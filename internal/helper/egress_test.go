@@ -0,0 +1,106 @@
+package helper
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEgressPolicyCheckIP(t *testing.T) {
+	policy := &EgressPolicy{Allowed: mustParseCIDRs("10.20.0.0/16", "fd00:1::/64")}
+
+	tests := []struct {
+		ip      string
+		allowed bool
+	}{
+		{"127.0.0.1", false},
+		{"127.8.9.10", false},
+		{"::1", false},
+		{"::ffff:127.0.0.1", false},
+		{"10.0.0.1", false},
+		{"172.16.5.4", false},
+		{"192.168.1.1", false},
+		{"::ffff:192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fc00::1", false},
+		{"0.0.0.0", false},
+		{"::", false},
+		{"100.64.0.1", false},
+		{"100.127.255.254", false},
+		{"198.18.0.1", false},
+		{"224.0.0.1", false},
+		{"10.20.3.4", true},
+		{"fd00:1::5", true},
+		{"100.128.0.1", true},
+		{"8.8.8.8", true},
+		{"2001:4860:4860::8888", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			err := policy.CheckIP(net.ParseIP(tt.ip))
+			if tt.allowed && err != nil {
+				t.Errorf("CheckIP(%s) error = %v, want allowed", tt.ip, err)
+			}
+			if !tt.allowed && err == nil {
+				t.Errorf("CheckIP(%s) allowed, want refused", tt.ip)
+			}
+		})
+	}
+}
+
+func TestEgressPolicyCheckURL(t *testing.T) {
+	tests := []struct {
+		name         string
+		requireHTTPS bool
+		url          string
+		allowed      bool
+	}{
+		{"public https", true, "https://8.8.8.8/hook", true},
+		{"public http", false, "http://8.8.8.8/hook", true},
+		{"http when https required", true, "http://8.8.8.8/hook", false},
+		{"loopback", false, "http://127.0.0.1:8080/hook", false},
+		{"mapped loopback", false, "http://[::ffff:127.0.0.1]/hook", false},
+		{"ipv6 loopback", false, "https://[::1]/hook", false},
+		{"private", false, "https://192.168.0.10/hook", false},
+		{"metadata", false, "http://169.254.169.254/latest/meta-data", false},
+		{"carrier-grade nat", false, "https://100.64.1.1/hook", false},
+		{"unsupported scheme", false, "ftp://8.8.8.8/hook", false},
+		{"missing host", false, "https:///hook", false},
+		{"not a url", false, "://hook", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &EgressPolicy{RequireHTTPS: tt.requireHTTPS}
+			err := policy.CheckURL(context.Background(), tt.url)
+			if tt.allowed && err != nil {
+				t.Errorf("CheckURL(%s) error = %v, want allowed", tt.url, err)
+			}
+			if !tt.allowed && err == nil {
+				t.Errorf("CheckURL(%s) allowed, want refused", tt.url)
+			}
+		})
+	}
+}
+
+func TestEgressPolicyTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	refused := &http.Client{Transport: (&EgressPolicy{}).Transport()}
+	if resp, err := refused.Get(server.URL); err == nil {
+		resp.Body.Close()
+		t.Error("Transport connected to a loopback address")
+	}
+
+	allowed := &http.Client{Transport: (&EgressPolicy{Allowed: mustParseCIDRs("127.0.0.0/8")}).Transport()}
+	resp, err := allowed.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Transport refused an allowed network: %v", err)
+	}
+	resp.Body.Close()
+}
@@ -0,0 +1,29 @@
+package helper
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+func ParseTenantID(tenantID string) (uint, error) {
+	id, err := strconv.ParseUint(tenantID, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid tenant ID format: %w", err)
+	}
+	return uint(id), nil
+}
+
+// TenantReference prefixes a tenant's external_id or reference_id so webhooks
+// can be routed back to the tenant.
+func TenantReference(tenantID, reference string) string {
+	return fmt.Sprintf("%s:%s", tenantID, reference)
+}
+
+// StripTenantReference returns the reference as the tenant originally sent it.
+func StripTenantReference(reference string) string {
+	if _, ref, ok := strings.Cut(reference, ":"); ok {
+		return ref
+	}
+	return reference
+}
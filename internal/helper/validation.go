@@ -0,0 +1,74 @@
+package helper
+
+import (
+	"math"
+	"regexp"
+)
+
+// SupportedCurrencies are the currencies Xendit settles for xenPlatform accounts.
+var SupportedCurrencies = []string{"IDR", "PHP", "VND", "THB", "MYR"}
+
+// DefaultCurrency is the currency Xendit assumes when a request names none.
+const DefaultCurrency = "IDR"
+
+// MaxAmount caps request amounts well above any real transaction to catch
+// unit mistakes before they reach Xendit.
+const MaxAmount = 1_000_000_000_000
+
+var (
+	emailRegex = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+	phoneRegex = regexp.MustCompile(`^\+[1-9][0-9]{7,14}$`)
+	urlRegex   = regexp.MustCompile(`^https?://[^\s/]+`)
+)
+
+func IsEmail(s string) bool {
+	return emailRegex.MatchString(s)
+}
+
+// IsPhone accepts E.164 numbers, which is the format Xendit expects.
+func IsPhone(s string) bool {
+	return phoneRegex.MatchString(s)
+}
+
+func IsWebhookURL(s string) bool {
+	return urlRegex.MatchString(s)
+}
+
+func IsSupportedCurrency(currency string) bool {
+	return containsCurrency(SupportedCurrencies, currency)
+}
+
+// IsAllowedCurrency checks currency against a tenant's allowed list, where an
+// empty list allows every supported currency.
+func IsAllowedCurrency(allowed []string, currency string) bool {
+	if len(allowed) == 0 {
+		return IsSupportedCurrency(currency)
+	}
+	return containsCurrency(allowed, currency)
+}
+
+func containsCurrency(currencies []string, currency string) bool {
+	for _, c := range currencies {
+		if c == currency {
+			return true
+		}
+	}
+	return false
+}
+
+// IsZeroDecimalCurrency reports currencies that Xendit only accepts as whole amounts.
+func IsZeroDecimalCurrency(currency string) bool {
+	return currency == "IDR" || currency == "VND"
+}
+
+func IsWholeNumber(f float64) bool {
+	return f == math.Trunc(f)
+}
+
+// RoundAmount rounds to the smallest unit Xendit accepts for the currency.
+func RoundAmount(amount float64, currency string) float64 {
+	if IsZeroDecimalCurrency(currency) {
+		return math.Round(amount)
+	}
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,30 @@
+package lib
+
+import "context"
+
+// Message is what a Publisher sends to the message bus. Key groups related
+// messages, e.g. as the Kafka partition key, and Attributes are metadata that
+// consumers can filter on without decoding Body, e.g. NATS or Kafka headers.
+type Message struct {
+	Key        string
+	Body       []byte
+	Attributes map[string]string
+}
+
+// Publisher sends messages to a message bus. Implementations must be safe for
+// concurrent use.
+type Publisher interface {
+	Publish(ctx context.Context, msg Message) error
+}
+
+type noopPublisher struct{}
+
+// NewNoopPublisher returns a Publisher that drops every message, for when no
+// message bus is configured.
+func NewNoopPublisher() Publisher {
+	return noopPublisher{}
+}
+
+func (noopPublisher) Publish(ctx context.Context, msg Message) error {
+	return nil
+}
@@ -10,6 +10,8 @@ import (
 type RedisLib interface {
 	Get(ctx context.Context, key string) (string, error)
 	Set(ctx context.Context, key string, value string, exp time.Duration) error
+	SetNX(ctx context.Context, key string, value string, exp time.Duration) (bool, error)
+	Run(ctx context.Context, script *redis.Script, keys []string, args ...interface{}) (interface{}, error)
 }
 
 type redisLib struct {
@@ -29,3 +31,13 @@ func (l *redisLib) Get(ctx context.Context, key string) (string, error) {
 func (l *redisLib) Set(ctx context.Context, key string, value string, exp time.Duration) error {
 	return l.client.Set(ctx, key, value, exp).Err()
 }
+
+// SetNX sets the key only when it doesn't exist yet and reports whether it did.
+func (l *redisLib) SetNX(ctx context.Context, key string, value string, exp time.Duration) (bool, error) {
+	return l.client.SetNX(ctx, key, value, exp).Result()
+}
+
+// Run executes a Lua script so several keys can be checked and updated atomically.
+func (l *redisLib) Run(ctx context.Context, script *redis.Script, keys []string, args ...interface{}) (interface{}, error) {
+	return script.Run(ctx, l.client, keys, args...).Result()
+}
@@ -0,0 +1,40 @@
+package lib
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type redisStreamPublisher struct {
+	client *redis.Client
+	stream string
+	maxLen int64
+}
+
+// NewRedisStreamPublisher appends messages to a Redis stream, trimming it to
+// about maxLen entries, or leaving it untrimmed when maxLen is 0. Each entry
+// has the message attributes as fields, plus key and body.
+func NewRedisStreamPublisher(client *redis.Client, stream string, maxLen int64) Publisher {
+	return &redisStreamPublisher{
+		client: client,
+		stream: stream,
+		maxLen: maxLen,
+	}
+}
+
+func (p *redisStreamPublisher) Publish(ctx context.Context, msg Message) error {
+	values := make(map[string]interface{}, len(msg.Attributes)+2)
+	for name, value := range msg.Attributes {
+		values[name] = value
+	}
+	values["key"] = msg.Key
+	values["body"] = msg.Body
+
+	return p.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: p.stream,
+		MaxLen: p.maxLen,
+		Approx: p.maxLen > 0,
+		Values: values,
+	}).Err()
+}
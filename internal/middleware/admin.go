@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"os"
+	"payment-broker/internal/helper"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AdminMiddleware accepts a personal key from ADMIN_API_KEYS, which sets
+// X-Admin-User to the admin's name, or the shared ADMIN_API_KEY, which
+// leaves it empty. Reviews that need a named admin check X-Admin-User.
+func AdminMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		adminKey := c.Get("X-Admin-Key")
+		expected := os.Getenv("ADMIN_API_KEY")
+
+		if adminKey == "" {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": "Admin key is required",
+			})
+		}
+
+		if name, ok := helper.AdminIdentity(adminKey); ok {
+			c.Locals("X-Admin-User", name)
+			return c.Next()
+		}
+
+		if expected == "" || subtle.ConstantTimeCompare([]byte(adminKey), []byte(expected)) != 1 {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": "Invalid admin key",
+			})
+		}
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"errors"
+	"payment-broker/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// UsageMiddleware meters each tenant call by route and outcome once the handler
+// has run. It must be registered after XenditMiddleware, which sets the tenant.
+func UsageMiddleware(usageService service.UsageService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		err := c.Next()
+
+		tenantID, ok := c.Locals("X-Tenant-ID").(string)
+		if !ok || tenantID == "" {
+			return err
+		}
+
+		status := c.Response().StatusCode()
+		if err != nil {
+			status = fiber.StatusInternalServerError
+			var fiberErr *fiber.Error
+			if errors.As(err, &fiberErr) {
+				status = fiberErr.Code
+			}
+		}
+
+		usageService.Record(tenantID, c.Method(), c.Route().Path, status)
+		return err
+	}
+}
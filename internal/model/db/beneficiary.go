@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+const (
+	BeneficiaryPending  = "PENDING_VERIFICATION"
+	BeneficiaryVerified = "VERIFIED"
+	BeneficiaryRejected = "REJECTED"
+)
+
+// Beneficiary is a payout destination a tenant registered. Only an operator
+// can verify it, so a leaked tenant API key can't add its own destinations.
+type Beneficiary struct {
+	ID                uint       `gorm:"primaryKey" json:"id"`
+	TenantID          uint       `gorm:"uniqueIndex:idx_beneficiary_destination" json:"tenant_id"`
+	ChannelCode       string     `gorm:"size:64;uniqueIndex:idx_beneficiary_destination" json:"channel_code"`
+	AccountNumber     string     `gorm:"size:64;uniqueIndex:idx_beneficiary_destination" json:"account_number"`
+	AccountHolderName string     `gorm:"size:128" json:"account_holder_name"`
+	Status            string     `gorm:"size:32;index" json:"status"`
+	ReviewedBy        string     `gorm:"size:128" json:"reviewed_by,omitempty"`
+	ReviewedAt        *time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
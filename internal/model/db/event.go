@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// TenantEvent is an event routed to a tenant, kept for the event stream and
+// polling API. ID orders the tenant's events and is their cursor; events are
+// only read once they are a few seconds old, as IDs may commit out of order.
+// Payload is the dto.Event as JSON, and the same Xendit callback delivered
+// twice is kept once. AcknowledgedAt is set once the tenant marks the event
+// consumed. Unpublished is set when the event is logged and cleared once the
+// relay has published it to the message bus; events logged before the relay
+// existed were published as they came in and have it NULL.
+type TenantEvent struct {
+	ID             uint   `gorm:"primaryKey"`
+	TenantID       uint   `gorm:"uniqueIndex:idx_tenant_event"`
+	EventID        string `gorm:"size:64;uniqueIndex:idx_tenant_event"`
+	Type           string `gorm:"size:64"`
+	Subject        string `gorm:"size:128"`
+	Payload        string `gorm:"type:text"`
+	OccurredAt     time.Time
+	AcknowledgedAt *time.Time
+	Unpublished    bool      `gorm:"index:idx_tenant_event_unpublished,where:unpublished"`
+	CreatedAt      time.Time `gorm:"index"`
+}
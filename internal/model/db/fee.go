@@ -0,0 +1,47 @@
+package model
+
+import "time"
+
+const (
+	FeeTypePercentage = "PERCENTAGE"
+	FeeTypeFlat       = "FLAT"
+	FeeTypeTiered     = "TIERED"
+)
+
+// FeeRule is one line of a tenant's fee schedule. PaymentMethod matches an
+// invoice's payment channel or method, and an empty one is the tenant default.
+// A rule with a Currency only applies to invoices in that currency, since flat
+// amounts and tier bounds mean nothing across currencies.
+type FeeRule struct {
+	ID            uint      `gorm:"primaryKey" json:"-"`
+	TenantID      uint      `gorm:"uniqueIndex:idx_fee_rule_method" json:"tenant_id"`
+	PaymentMethod string    `gorm:"size:64;uniqueIndex:idx_fee_rule_method" json:"payment_method"`
+	Currency      string    `gorm:"size:3;uniqueIndex:idx_fee_rule_method" json:"currency,omitempty"`
+	Type          string    `gorm:"size:16" json:"type"`
+	Percentage    float64   `json:"percentage"`
+	FlatAmount    float64   `json:"flat_amount"`
+	Tiers         []FeeTier `gorm:"serializer:json" json:"tiers,omitempty"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// FeeTier applies to amounts up to UpTo, and a zero UpTo has no upper bound.
+type FeeTier struct {
+	UpTo       float64 `json:"up_to"`
+	Percentage float64 `json:"percentage"`
+	FlatAmount float64 `json:"flat_amount"`
+}
+
+// PlatformFee is the fee the platform expects to earn from one paid invoice.
+type PlatformFee struct {
+	ID             uint      `gorm:"primaryKey" json:"-"`
+	TenantID       uint      `gorm:"index:idx_platform_fee_period" json:"tenant_id"`
+	InvoiceID      string    `gorm:"size:64;uniqueIndex" json:"invoice_id"`
+	ExternalID     string    `gorm:"size:128" json:"external_id"`
+	PaymentMethod  string    `gorm:"size:64" json:"payment_method"`
+	PaymentChannel string    `gorm:"size:64" json:"payment_channel"`
+	Currency       string    `gorm:"size:3" json:"currency"`
+	Amount         float64   `json:"amount"`
+	Fee            float64   `json:"fee"`
+	PaidAt         time.Time `gorm:"index:idx_platform_fee_period" json:"paid_at"`
+	CreatedAt      time.Time `json:"created_at"`
+}
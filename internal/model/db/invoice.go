@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// Invoice keeps the latest known state of a tenant invoice from the create
+// response and invoice callbacks.
+type Invoice struct {
+	ID             uint       `gorm:"primaryKey" json:"-"`
+	TenantID       uint       `gorm:"index" json:"-"`
+	InvoiceID      string     `gorm:"size:64;uniqueIndex" json:"id"`
+	ExternalID     string     `gorm:"size:128" json:"external_id"`
+	Currency       string     `gorm:"size:3" json:"currency"`
+	Amount         float64    `json:"amount"`
+	Status         string     `gorm:"size:32" json:"status"`
+	PaymentMethod  string     `gorm:"size:64" json:"payment_method,omitempty"`
+	PaymentChannel string     `gorm:"size:64" json:"payment_channel,omitempty"`
+	PaidAt         *time.Time `json:"paid_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
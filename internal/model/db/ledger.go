@@ -0,0 +1,38 @@
+package model
+
+import "time"
+
+// Ledger accounts kept per tenant and currency. XenditBalance mirrors the
+// tenant's funds at Xendit, TenantReceivable is what the broker owes the tenant,
+// and Refunds counts what was returned to payers out of the tenant's funds.
+const (
+	LedgerXenditBalance      = "XENDIT_BALANCE"
+	LedgerTenantReceivable   = "TENANT_RECEIVABLE"
+	LedgerPlatformFeeRevenue = "PLATFORM_FEE_REVENUE"
+	LedgerPayoutsInFlight    = "PAYOUTS_IN_FLIGHT"
+	LedgerRefunds            = "REFUNDS"
+)
+
+// LedgerEntry is one balanced posting. Key identifies the business event it
+// came from, so a webhook delivered twice is only posted once.
+type LedgerEntry struct {
+	ID         uint         `gorm:"primaryKey" json:"id"`
+	TenantID   uint         `gorm:"index" json:"tenant_id"`
+	Key        string       `gorm:"size:160;uniqueIndex" json:"key"`
+	Event      string       `gorm:"size:64" json:"event"`
+	ResourceID string       `gorm:"size:64" json:"resource_id"`
+	Reference  string       `gorm:"size:128" json:"reference"`
+	Currency   string       `gorm:"size:3" json:"currency"`
+	Lines      []LedgerLine `gorm:"foreignKey:EntryID" json:"lines"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+type LedgerLine struct {
+	ID       uint    `gorm:"primaryKey" json:"-"`
+	EntryID  uint    `gorm:"index" json:"-"`
+	TenantID uint    `gorm:"index:idx_ledger_line_account" json:"-"`
+	Account  string  `gorm:"size:32;index:idx_ledger_line_account" json:"account"`
+	Currency string  `gorm:"size:3" json:"-"`
+	Debit    float64 `json:"debit"`
+	Credit   float64 `json:"credit"`
+}
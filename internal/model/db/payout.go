@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// Payout keeps the latest known state of a tenant payout, including the
+// failure code reported by payout.failed webhooks.
+type Payout struct {
+	ID          uint      `gorm:"primaryKey" json:"-"`
+	TenantID    uint      `gorm:"index" json:"-"`
+	PayoutID    string    `gorm:"size:64;uniqueIndex" json:"id"`
+	ReferenceID string    `gorm:"size:128" json:"reference_id"`
+	ChannelCode string    `gorm:"size:64" json:"channel_code"`
+	Currency    string    `gorm:"size:3" json:"currency"`
+	Amount      float64   `json:"amount"`
+	Status      string    `gorm:"size:32" json:"status"`
+	FailureCode string    `gorm:"size:64" json:"failure_code,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
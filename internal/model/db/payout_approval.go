@@ -0,0 +1,42 @@
+package model
+
+import "time"
+
+const (
+	PayoutApprovalPending    = "PENDING_APPROVAL"
+	PayoutApprovalProcessing = "PROCESSING"
+	PayoutApprovalApproved   = "APPROVED"
+	PayoutApprovalRejected   = "REJECTED"
+	PayoutApprovalFailed     = "FAILED"
+)
+
+// PayoutApproval is a payout held for four-eyes review. Payload is the body
+// that is sent to Xendit once approved, with the tenant prefix already applied.
+type PayoutApproval struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	TenantID    uint       `gorm:"index" json:"tenant_id"`
+	ReferenceID string     `gorm:"size:128" json:"reference_id"`
+	ChannelCode string     `gorm:"size:64" json:"channel_code"`
+	Currency    string     `gorm:"size:3" json:"currency"`
+	Amount      float64    `json:"amount"`
+	Payload     string     `gorm:"type:text" json:"-"`
+	Status      string     `gorm:"size:32;index" json:"status"`
+	RequestedBy string     `gorm:"size:128" json:"requested_by"`
+	ReviewedBy  string     `gorm:"size:128" json:"reviewed_by,omitempty"`
+	ReviewedAt  *time.Time `json:"reviewed_at,omitempty"`
+	Reason      string     `gorm:"size:512" json:"reason,omitempty"`
+	PayoutID    string     `gorm:"size:64" json:"payout_id,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// PayoutApprovalEvent is the append-only audit trail of an approval. Rows are
+// only ever inserted.
+type PayoutApprovalEvent struct {
+	ID         uint      `gorm:"primaryKey" json:"-"`
+	ApprovalID uint      `gorm:"index" json:"approval_id"`
+	Action     string    `gorm:"size:32" json:"action"`
+	Actor      string    `gorm:"size:128" json:"actor"`
+	Note       string    `gorm:"size:512" json:"note,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
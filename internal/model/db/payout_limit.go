@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+const (
+	BeneficiaryPolicyReject   = "REJECT"
+	BeneficiaryPolicyApproval = "APPROVAL"
+)
+
+// PayoutLimit holds a tenant's payout controls. A zero value disables that
+// limit and an empty AllowedChannels allows every channel. Amount limits and
+// the approval threshold are set per currency in Currencies; once any currency
+// is configured, payouts in the others are refused.
+type PayoutLimit struct {
+	ID              uint                  `gorm:"primaryKey" json:"-"`
+	TenantID        uint                  `gorm:"uniqueIndex" json:"tenant_id"`
+	HourlyCount     int                   `json:"hourly_count"`
+	AllowedChannels string                `gorm:"size:512" json:"allowed_channels"`
+	Currencies      []PayoutCurrencyLimit `gorm:"serializer:json" json:"currencies"`
+	// BeneficiaryPolicy decides what happens to payouts to destinations that
+	// aren't verified beneficiaries: REJECT, or APPROVAL, which is also what
+	// an empty policy means.
+	BeneficiaryPolicy string    `gorm:"size:16" json:"beneficiary_policy"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// PayoutCurrencyLimit holds the amount limits for payouts in one currency.
+type PayoutCurrencyLimit struct {
+	Currency        string  `json:"currency"`
+	MaxSingleAmount float64 `json:"max_single_amount"`
+	DailyAmount     float64 `json:"daily_amount"`
+	MonthlyAmount   float64 `json:"monthly_amount"`
+	// ApprovalThreshold holds payouts at or above this amount for approval.
+	ApprovalThreshold float64 `json:"approval_threshold"`
+}
@@ -0,0 +1,46 @@
+package model
+
+import "time"
+
+const (
+	ReconciliationRunning   = "RUNNING"
+	ReconciliationCompleted = "COMPLETED"
+	ReconciliationPartial   = "PARTIAL"
+
+	MismatchMissingLocally = "MISSING_LOCALLY"
+	MismatchMissingWebhook = "MISSING_WEBHOOK"
+	MismatchStatusDrift    = "STATUS_DRIFT"
+	MismatchAmountDrift    = "AMOUNT_DRIFT"
+)
+
+// ReconciliationRun compares the invoices and payouts Xendit has for a window
+// with what the broker stored. Error lists the tenants that couldn't be checked.
+type ReconciliationRun struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	WindowStart time.Time  `json:"window_start"`
+	WindowEnd   time.Time  `json:"window_end"`
+	SelfHeal    bool       `json:"self_heal"`
+	Status      string     `gorm:"size:16" json:"status"`
+	Checked     int        `json:"checked"`
+	Mismatches  int        `json:"mismatches"`
+	Healed      int        `json:"healed"`
+	Error       string     `gorm:"type:text" json:"error,omitempty"`
+	StartedAt   time.Time  `json:"started_at"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty"`
+}
+
+type ReconciliationMismatch struct {
+	ID           uint      `gorm:"primaryKey" json:"-"`
+	RunID        uint      `gorm:"index" json:"run_id"`
+	TenantID     uint      `json:"tenant_id"`
+	Resource     string    `gorm:"size:16" json:"resource"`
+	ResourceID   string    `gorm:"size:64" json:"resource_id"`
+	Reference    string    `gorm:"size:128" json:"reference"`
+	Type         string    `gorm:"size:32" json:"type"`
+	LocalStatus  string    `gorm:"size:32" json:"local_status,omitempty"`
+	RemoteStatus string    `gorm:"size:32" json:"remote_status"`
+	LocalAmount  float64   `json:"local_amount,omitempty"`
+	RemoteAmount float64   `json:"remote_amount"`
+	Healed       bool      `json:"healed"`
+	CreatedAt    time.Time `json:"created_at"`
+}
@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// SplitRule attaches a Xendit split rule to a tenant. An empty Endpoint is the
+// tenant's default rule, used when no endpoint-specific rule exists.
+type SplitRule struct {
+	ID                uint   `gorm:"primaryKey"`
+	TenantID          uint   `gorm:"uniqueIndex:idx_split_rule_tenant_endpoint"`
+	Endpoint          string `gorm:"size:64;uniqueIndex:idx_split_rule_tenant_endpoint"`
+	XenditSplitRuleID string `gorm:"size:64"`
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+// SubscriptionPlan mirrors a Xendit recurring plan so tenants can reconcile
+// without calling Xendit. ReferenceID is stored without the tenant prefix.
+type SubscriptionPlan struct {
+	ID          uint      `gorm:"primaryKey" json:"-"`
+	TenantID    uint      `gorm:"index" json:"-"`
+	PlanID      string    `gorm:"size:64;uniqueIndex" json:"id"`
+	ReferenceID string    `gorm:"size:128" json:"reference_id"`
+	CustomerID  string    `gorm:"size:64" json:"customer_id"`
+	Currency    string    `gorm:"size:3" json:"currency"`
+	Amount      float64   `json:"amount"`
+	Status      string    `gorm:"size:32" json:"status"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type SubscriptionCycle struct {
+	ID           uint       `gorm:"primaryKey" json:"-"`
+	TenantID     uint       `gorm:"index" json:"-"`
+	PlanID       string     `gorm:"size:64;index" json:"plan_id"`
+	CycleID      string     `gorm:"size:64;uniqueIndex" json:"id"`
+	CycleNumber  int        `json:"cycle_number"`
+	AttemptCount int        `json:"attempt_count"`
+	Currency     string     `gorm:"size:3" json:"currency"`
+	Amount       float64    `json:"amount"`
+	Status       string     `gorm:"size:32" json:"status"`
+	FailureCode  string     `gorm:"size:64" json:"failure_code,omitempty"`
+	ScheduledAt  *time.Time `json:"scheduled_timestamp,omitempty"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
@@ -1,9 +1,16 @@
 package model
 
 type Tenant struct {
-	ID         uint   `gorm:"primaryKey"`
-	AccountID  string `gorm:"size:24"`
-	WebhookURL string `gorm:"size:256"`
-	APIKey     string `gorm:"size:12"`
-	Name       string `gorm:"size:64"`
+	ID            uint   `gorm:"primaryKey"`
+	AccountID     string `gorm:"size:24"`
+	AccountType   string `gorm:"size:16"`
+	AccountStatus string `gorm:"size:16"`
+	Email         string `gorm:"size:128"`
+	WebhookURL    string `gorm:"size:256"`
+	APIKey        string `gorm:"size:12"`
+	Name          string `gorm:"size:64"`
+	// AllowedCurrencies is a comma separated list, and empty allows every
+	// currency Xendit supports. DefaultCurrency fills requests without one.
+	AllowedCurrencies string `gorm:"size:32"`
+	DefaultCurrency   string `gorm:"size:3"`
 }
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+const (
+	UsageSuccess     = "SUCCESS"
+	UsageClientError = "CLIENT_ERROR"
+	UsageServerError = "SERVER_ERROR"
+)
+
+// UsageRecord counts a tenant's calls to one endpoint with one outcome on a
+// UTC day, which is what tenants are billed on.
+type UsageRecord struct {
+	ID        uint      `gorm:"primaryKey" json:"-"`
+	TenantID  uint      `gorm:"uniqueIndex:idx_usage_bucket" json:"tenant_id"`
+	Day       time.Time `gorm:"type:date;uniqueIndex:idx_usage_bucket" json:"day"`
+	Method    string    `gorm:"size:8;uniqueIndex:idx_usage_bucket" json:"method"`
+	Endpoint  string    `gorm:"size:128;uniqueIndex:idx_usage_bucket" json:"endpoint"`
+	Outcome   string    `gorm:"size:16;uniqueIndex:idx_usage_bucket" json:"outcome"`
+	Count     int64     `json:"count"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,50 @@
+package model
+
+import "time"
+
+const (
+	BreakerClosed   = "CLOSED"
+	BreakerOpen     = "OPEN"
+	BreakerHalfOpen = "HALF_OPEN"
+)
+
+// WebhookBreaker is the circuit breaker of one tenant webhook URL. Failures
+// counts consecutive failed deliveries. While it is OPEN, or HALF_OPEN as the
+// worker probes it, deliveries are queued instead of attempted.
+type WebhookBreaker struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	TenantID    uint       `gorm:"uniqueIndex:idx_webhook_breaker" json:"tenant_id"`
+	URL         string     `gorm:"size:256;uniqueIndex:idx_webhook_breaker" json:"url"`
+	State       string     `gorm:"size:16;default:CLOSED" json:"state"`
+	Failures    int        `json:"failures"`
+	LastError   string     `gorm:"type:text" json:"last_error,omitempty"`
+	OpenedAt    *time.Time `json:"opened_at,omitempty"`
+	NextProbeAt *time.Time `gorm:"index" json:"next_probe_at,omitempty"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// QueuedWebhook is a delivery held back by an open breaker, or, with no
+// BreakerID, one waiting its turn for an ordered endpoint, where deliveries
+// for the same tenant, URL and ResourceID go out one at a time. Event is the
+// dto.Event as JSON, and Format and URL are the endpoint's when it was queued.
+// NextAttemptAt is when an ordered delivery may be tried again, after a
+// failure or while a worker holds it. An ordered delivery that used up its
+// attempts is dead-lettered: it is no longer tried but still holds back its
+// resource until an admin retries or skips it.
+type QueuedWebhook struct {
+	ID             uint `gorm:"primaryKey"`
+	BreakerID      uint `gorm:"index"`
+	TenantID       uint `gorm:"index;index:idx_queued_webhook_resource"`
+	EndpointID     uint
+	URL            string `gorm:"size:256;index:idx_queued_webhook_resource"`
+	ResourceID     string `gorm:"size:128;index:idx_queued_webhook_resource"`
+	Format         string `gorm:"size:16"`
+	EventID        string `gorm:"size:64"`
+	Event          string `gorm:"type:text"`
+	APIKey         string `gorm:"size:128"`
+	Attempts       int
+	LastError      string `gorm:"type:text"`
+	NextAttemptAt  *time.Time
+	DeadLetteredAt *time.Time
+	CreatedAt      time.Time
+}
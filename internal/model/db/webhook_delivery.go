@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// WebhookDelivery is one attempt to deliver an event to a tenant endpoint.
+// EndpointID is zero for the tenant's onboarding webhook URL. ResponseStatus
+// is zero and Error is set when the endpoint couldn't be reached, and
+// ResponseBody keeps only the start of the response.
+type WebhookDelivery struct {
+	ID             uint              `gorm:"primaryKey" json:"id"`
+	TenantID       uint              `gorm:"index:idx_webhook_delivery_tenant" json:"tenant_id"`
+	EndpointID     uint              `json:"endpoint_id"`
+	EventID        string            `gorm:"size:64;index" json:"event_id"`
+	EventType      string            `gorm:"size:64" json:"event_type"`
+	URL            string            `gorm:"size:256" json:"url"`
+	RequestHeaders map[string]string `gorm:"type:text;serializer:json" json:"request_headers"`
+	RequestBody    string            `gorm:"type:text" json:"request_body"`
+	ResponseStatus int               `json:"response_status"`
+	ResponseBody   string            `gorm:"type:text" json:"response_body,omitempty"`
+	LatencyMs      int64             `json:"latency_ms"`
+	Succeeded      bool              `json:"succeeded"`
+	Error          string            `gorm:"type:text" json:"error,omitempty"`
+	CreatedAt      time.Time         `gorm:"index:idx_webhook_delivery_tenant" json:"created_at"`
+}
@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// Webhook endpoint formats: raw forwards Xendit's payload unchanged and
+// envelope.v1 wraps it in dto.Event.
+const (
+	WebhookFormatRaw        = "raw"
+	WebhookFormatEnvelopeV1 = "envelope.v1"
+)
+
+// WebhookEndpoint is one of a tenant's webhook URLs. EventTypes is a comma
+// separated list of event types or patterns, e.g. invoice.* or payout.failed,
+// and * subscribes to every event. An Ordered endpoint gets the events of one
+// resource one at a time, in the order the broker received them.
+type WebhookEndpoint struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	TenantID   uint      `gorm:"index" json:"tenant_id"`
+	URL        string    `gorm:"size:256" json:"url"`
+	EventTypes string    `gorm:"size:512" json:"event_types"`
+	Format     string    `gorm:"size:16;default:raw" json:"format"`
+	Ordered    bool      `json:"ordered"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
@@ -0,0 +1,203 @@
+package dto
+
+type InvoiceCustomer struct {
+	GivenNames   string `json:"given_names"`
+	Email        string `json:"email"`
+	MobileNumber string `json:"mobile_number"`
+}
+
+type InvoiceRequest struct {
+	ExternalID      string           `json:"external_id"`
+	Amount          float64          `json:"amount"`
+	Currency        string           `json:"currency"`
+	PayerEmail      string           `json:"payer_email"`
+	Description     string           `json:"description"`
+	InvoiceDuration int              `json:"invoice_duration"`
+	Customer        *InvoiceCustomer `json:"customer"`
+
+	allowedCurrencies []string
+}
+
+func (r *InvoiceRequest) ApplyTenantCurrencies(allowed []string, defaultCurrency string) string {
+	r.allowedCurrencies = allowed
+	if r.Currency == "" {
+		r.Currency = defaultCurrency
+	}
+	return r.Currency
+}
+
+func (r *InvoiceRequest) Validate() ValidationErrors {
+	var errs ValidationErrors
+	errs.required("external_id", r.ExternalID)
+	errs.currency("currency", r.Currency, r.allowedCurrencies)
+	errs.amount("amount", r.Amount, r.Currency)
+	errs.email("payer_email", r.PayerEmail)
+
+	if r.InvoiceDuration < 0 {
+		errs.add("invoice_duration", "must not be negative")
+	}
+
+	if r.Customer != nil {
+		errs.email("customer.email", r.Customer.Email)
+		errs.phone("customer.mobile_number", r.Customer.MobileNumber)
+	}
+
+	return errs
+}
+
+type PlanSchedule struct {
+	ReferenceID     string `json:"reference_id"`
+	Interval        string `json:"interval"`
+	IntervalCount   int    `json:"interval_count"`
+	TotalRecurrence int    `json:"total_recurrence"`
+}
+
+type PlanRequest struct {
+	ReferenceID     string        `json:"reference_id"`
+	CustomerID      string        `json:"customer_id"`
+	RecurringAction string        `json:"recurring_action"`
+	Currency        string        `json:"currency"`
+	Amount          float64       `json:"amount"`
+	Schedule        *PlanSchedule `json:"schedule"`
+
+	allowedCurrencies []string
+}
+
+func (r *PlanRequest) ApplyTenantCurrencies(allowed []string, defaultCurrency string) string {
+	r.allowedCurrencies = allowed
+	if r.Currency == "" {
+		r.Currency = defaultCurrency
+	}
+	return r.Currency
+}
+
+func (r *PlanRequest) Validate() ValidationErrors {
+	var errs ValidationErrors
+	errs.required("reference_id", r.ReferenceID)
+	errs.required("customer_id", r.CustomerID)
+	errs.oneOf("recurring_action", r.RecurringAction, "PAYMENT")
+	errs.currency("currency", r.Currency, r.allowedCurrencies)
+	errs.amount("amount", r.Amount, r.Currency)
+
+	if r.Schedule == nil {
+		errs.add("schedule", "is required")
+		return errs
+	}
+
+	errs.required("schedule.reference_id", r.Schedule.ReferenceID)
+	errs.oneOf("schedule.interval", r.Schedule.Interval, "DAY", "WEEK", "MONTH")
+	if r.Schedule.IntervalCount < 1 {
+		errs.add("schedule.interval_count", "must be at least 1")
+	}
+	if r.Schedule.TotalRecurrence < 0 {
+		errs.add("schedule.total_recurrence", "must not be negative")
+	}
+
+	return errs
+}
+
+type PayoutChannelProperties struct {
+	AccountHolderName string `json:"account_holder_name"`
+	AccountNumber     string `json:"account_number"`
+}
+
+type PayoutReceiptNotification struct {
+	EmailTo  []string `json:"email_to"`
+	EmailCC  []string `json:"email_cc"`
+	EmailBCC []string `json:"email_bcc"`
+}
+
+type PayoutRequest struct {
+	ReferenceID         string                     `json:"reference_id"`
+	ChannelCode         string                     `json:"channel_code"`
+	ChannelProperties   *PayoutChannelProperties   `json:"channel_properties"`
+	Amount              float64                    `json:"amount"`
+	Currency            string                     `json:"currency"`
+	Description         string                     `json:"description"`
+	ReceiptNotification *PayoutReceiptNotification `json:"receipt_notification"`
+
+	allowedCurrencies []string
+}
+
+func (r *PayoutRequest) ApplyTenantCurrencies(allowed []string, defaultCurrency string) string {
+	r.allowedCurrencies = allowed
+	if r.Currency == "" {
+		r.Currency = defaultCurrency
+	}
+	return r.Currency
+}
+
+func (r *PayoutRequest) Validate() ValidationErrors {
+	var errs ValidationErrors
+	errs.required("reference_id", r.ReferenceID)
+	errs.required("channel_code", r.ChannelCode)
+	errs.currency("currency", r.Currency, r.allowedCurrencies)
+	errs.amount("amount", r.Amount, r.Currency)
+
+	if r.ChannelProperties == nil {
+		errs.add("channel_properties", "is required")
+	} else {
+		errs.required("channel_properties.account_holder_name", r.ChannelProperties.AccountHolderName)
+		errs.required("channel_properties.account_number", r.ChannelProperties.AccountNumber)
+	}
+
+	if r.ReceiptNotification != nil {
+		for _, list := range []struct {
+			field  string
+			emails []string
+		}{
+			{"receipt_notification.email_to", r.ReceiptNotification.EmailTo},
+			{"receipt_notification.email_cc", r.ReceiptNotification.EmailCC},
+			{"receipt_notification.email_bcc", r.ReceiptNotification.EmailBCC},
+		} {
+			for _, email := range list.emails {
+				errs.email(list.field, email)
+			}
+		}
+	}
+
+	return errs
+}
+
+type CustomerIndividualDetail struct {
+	GivenNames string `json:"given_names"`
+	Surname    string `json:"surname"`
+}
+
+type CustomerBusinessDetail struct {
+	BusinessName string `json:"business_name"`
+}
+
+type CustomerRequest struct {
+	ReferenceID      string                    `json:"reference_id"`
+	Type             string                    `json:"type"`
+	Email            string                    `json:"email"`
+	MobileNumber     string                    `json:"mobile_number"`
+	IndividualDetail *CustomerIndividualDetail `json:"individual_detail"`
+	BusinessDetail   *CustomerBusinessDetail   `json:"business_detail"`
+}
+
+func (r *CustomerRequest) Validate() ValidationErrors {
+	var errs ValidationErrors
+	errs.required("reference_id", r.ReferenceID)
+	errs.oneOf("type", r.Type, "INDIVIDUAL", "BUSINESS")
+	errs.email("email", r.Email)
+	errs.phone("mobile_number", r.MobileNumber)
+
+	switch r.Type {
+	case "INDIVIDUAL":
+		if r.IndividualDetail == nil {
+			errs.add("individual_detail", "is required for INDIVIDUAL customers")
+		} else {
+			errs.required("individual_detail.given_names", r.IndividualDetail.GivenNames)
+		}
+	case "BUSINESS":
+		if r.BusinessDetail == nil {
+			errs.add("business_detail", "is required for BUSINESS customers")
+		} else {
+			errs.required("business_detail.business_name", r.BusinessDetail.BusinessName)
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,15 @@
+package dto
+
+type BeneficiaryRequest struct {
+	ChannelCode       string `json:"channel_code"`
+	AccountNumber     string `json:"account_number"`
+	AccountHolderName string `json:"account_holder_name"`
+}
+
+func (r *BeneficiaryRequest) Validate() ValidationErrors {
+	var errs ValidationErrors
+	errs.required("channel_code", r.ChannelCode)
+	errs.required("account_number", r.AccountNumber)
+	errs.required("account_holder_name", r.AccountHolderName)
+	return errs
+}
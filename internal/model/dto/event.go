@@ -0,0 +1,68 @@
+package dto
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// EventSpecVersion is the CloudEvents version the envelope follows, and
+// EventVersion is the version of the broker's own fields. EventVersion only
+// changes when a field is removed or changes meaning, so tenants can check it
+// before reading the envelope.
+const (
+	EventSpecVersion = "1.0"
+	EventVersion     = "v1"
+)
+
+// Event is the normalized envelope delivered to webhook endpoints that opt in
+// to it. Type is the same for event-style and legacy callbacks, e.g.
+// invoice.paid, and ID stays the same when Xendit retries a callback. Data is
+// the payload exactly as Xendit sent it.
+type Event struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Type            string          `json:"type"`
+	Source          string          `json:"source"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            time.Time       `json:"time"`
+	TenantID        uint            `json:"tenantid"`
+	Version         string          `json:"version"`
+	DataContentType string          `json:"datacontenttype"`
+	Data            json.RawMessage `json:"data"`
+}
+
+// LoggedEvent is an event from the tenant's event log. Cursor is its position
+// in the log, which is also its event stream ID.
+type LoggedEvent struct {
+	Cursor         uint       `json:"cursor"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+	Event
+}
+
+// EventFilter selects up to Limit events logged after the After cursor.
+// Pending leaves out events the tenant has acknowledged.
+type EventFilter struct {
+	After   uint
+	Limit   int
+	Pending bool
+}
+
+// MaxEventAck caps how many events one acknowledgement can mark.
+const MaxEventAck = 500
+
+// EventAckRequest marks the events at Cursors as consumed.
+type EventAckRequest struct {
+	Cursors []uint `json:"cursors"`
+}
+
+func (r *EventAckRequest) Validate() ValidationErrors {
+	var errs ValidationErrors
+	switch {
+	case len(r.Cursors) == 0:
+		errs.add("cursors", "must list at least one event cursor")
+	case len(r.Cursors) > MaxEventAck:
+		errs.add("cursors", fmt.Sprintf("must not list more than %d event cursors", MaxEventAck))
+	}
+	return errs
+}
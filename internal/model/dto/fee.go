@@ -0,0 +1,94 @@
+package dto
+
+import (
+	"fmt"
+	model "payment-broker/internal/model/db"
+)
+
+type FeeRuleRequest struct {
+	PaymentMethod string           `json:"payment_method"`
+	Currency      string           `json:"currency"`
+	Type          string           `json:"type"`
+	Percentage    float64          `json:"percentage"`
+	FlatAmount    float64          `json:"flat_amount"`
+	Tiers         []FeeTierRequest `json:"tiers"`
+}
+
+type FeeTierRequest struct {
+	UpTo       float64 `json:"up_to"`
+	Percentage float64 `json:"percentage"`
+	FlatAmount float64 `json:"flat_amount"`
+}
+
+type FeeScheduleRequest struct {
+	Rules []FeeRuleRequest `json:"rules"`
+}
+
+func (r *FeeScheduleRequest) Validate() ValidationErrors {
+	var errs ValidationErrors
+	seen := map[string]bool{}
+
+	for i, rule := range r.Rules {
+		field := fmt.Sprintf("rules[%d]", i)
+
+		key := rule.PaymentMethod + "/" + rule.Currency
+		if seen[key] {
+			errs.add(field+".payment_method", "is already used by another rule in the same currency")
+		}
+		seen[key] = true
+
+		if rule.Currency != "" {
+			errs.currency(field+".currency", rule.Currency, nil)
+		}
+
+		errs.oneOf(field+".type", rule.Type, "PERCENTAGE", "FLAT", "TIERED")
+		if rule.Percentage < 0 || rule.Percentage > 100 {
+			errs.add(field+".percentage", "must be between 0 and 100")
+		}
+		if rule.FlatAmount < 0 {
+			errs.add(field+".flat_amount", "must not be negative")
+		}
+
+		if rule.Type != "TIERED" {
+			continue
+		}
+		if len(rule.Tiers) == 0 {
+			errs.add(field+".tiers", "is required for TIERED rules")
+		}
+		for j, tier := range rule.Tiers {
+			tierField := fmt.Sprintf("%s.tiers[%d]", field, j)
+			if tier.Percentage < 0 || tier.Percentage > 100 {
+				errs.add(tierField+".percentage", "must be between 0 and 100")
+			}
+			if tier.FlatAmount < 0 {
+				errs.add(tierField+".flat_amount", "must not be negative")
+			}
+			if tier.UpTo < 0 {
+				errs.add(tierField+".up_to", "must not be negative, use 0 for no upper bound")
+			} else if j > 0 {
+				// Tiers are matched in order, so bounds must increase and only
+				// the last tier may be unbounded.
+				prev := rule.Tiers[j-1].UpTo
+				if prev == 0 || (tier.UpTo != 0 && tier.UpTo <= prev) {
+					errs.add(tierField+".up_to", "must be greater than the previous tier's up_to")
+				}
+			}
+		}
+	}
+
+	return errs
+}
+
+type FeeReportTotal struct {
+	Currency string  `json:"currency"`
+	Count    int     `json:"count"`
+	Amount   float64 `json:"amount"`
+	Fee      float64 `json:"fee"`
+}
+
+type FeeReport struct {
+	TenantID uint                `json:"tenant_id"`
+	Month    string              `json:"month"`
+	Totals   []FeeReportTotal    `json:"totals"`
+	Fees     []model.PlatformFee `json:"fees"`
+}
@@ -0,0 +1,15 @@
+package dto
+
+// XenditInvoice is an invoice as Xendit returns it from the API and sends it in
+// the legacy invoice callback, which has no event envelope.
+type XenditInvoice struct {
+	ID             string  `json:"id"`
+	ExternalID     string  `json:"external_id"`
+	Status         string  `json:"status"`
+	Amount         float64 `json:"amount"`
+	PaidAmount     float64 `json:"paid_amount"`
+	Currency       string  `json:"currency"`
+	PaymentMethod  string  `json:"payment_method"`
+	PaymentChannel string  `json:"payment_channel"`
+	PaidAt         string  `json:"paid_at"`
+}
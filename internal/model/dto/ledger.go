@@ -0,0 +1,35 @@
+package dto
+
+// XenditRefund is the data of a refund.succeeded or refund.failed webhook.
+type XenditRefund struct {
+	ID          string  `json:"id"`
+	InvoiceID   string  `json:"invoice_id"`
+	ReferenceID string  `json:"reference_id"`
+	Currency    string  `json:"currency"`
+	Amount      float64 `json:"amount"`
+	Status      string  `json:"status"`
+}
+
+// LedgerAccountTotal is the sum of one account's lines in one currency.
+type LedgerAccountTotal struct {
+	Account  string
+	Currency string
+	Debit    float64
+	Credit   float64
+}
+
+// LedgerBalance holds a tenant's account balances in one currency, each on its
+// normal side. Available is the receivable less refunds. XenditBalance and
+// Difference are only set when Xendit's cash balance could be fetched.
+type LedgerBalance struct {
+	Currency      string             `json:"currency"`
+	Accounts      map[string]float64 `json:"accounts"`
+	Available     float64            `json:"available"`
+	XenditBalance *float64           `json:"xendit_balance,omitempty"`
+	Difference    *float64           `json:"difference,omitempty"`
+}
+
+type LedgerBalances struct {
+	TenantID uint            `json:"tenant_id"`
+	Balances []LedgerBalance `json:"balances"`
+}
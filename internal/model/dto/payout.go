@@ -0,0 +1,11 @@
+package dto
+
+type XenditPayout struct {
+	ID          string  `json:"id"`
+	ReferenceID string  `json:"reference_id"`
+	ChannelCode string  `json:"channel_code"`
+	Currency    string  `json:"currency"`
+	Amount      float64 `json:"amount"`
+	Status      string  `json:"status"`
+	FailureCode string  `json:"failure_code"`
+}
@@ -0,0 +1,54 @@
+package dto
+
+import "fmt"
+
+type PayoutLimitRequest struct {
+	HourlyCount       int                          `json:"hourly_count"`
+	AllowedChannels   []string                     `json:"allowed_channels"`
+	Currencies        []PayoutCurrencyLimitRequest `json:"currencies"`
+	BeneficiaryPolicy string                       `json:"beneficiary_policy"`
+}
+
+type PayoutCurrencyLimitRequest struct {
+	Currency          string  `json:"currency"`
+	MaxSingleAmount   float64 `json:"max_single_amount"`
+	DailyAmount       float64 `json:"daily_amount"`
+	MonthlyAmount     float64 `json:"monthly_amount"`
+	ApprovalThreshold float64 `json:"approval_threshold"`
+}
+
+func (r *PayoutLimitRequest) Validate() ValidationErrors {
+	var errs ValidationErrors
+	if r.HourlyCount < 0 {
+		errs.add("hourly_count", "must not be negative, use 0 for no limit")
+	}
+
+	seen := map[string]bool{}
+	for i, currency := range r.Currencies {
+		field := fmt.Sprintf("currencies[%d]", i)
+		errs.currency(field+".currency", currency.Currency, nil)
+		if seen[currency.Currency] {
+			errs.add(field+".currency", "is listed twice")
+		}
+		seen[currency.Currency] = true
+
+		for _, limit := range []struct {
+			field string
+			value float64
+		}{
+			{"max_single_amount", currency.MaxSingleAmount},
+			{"daily_amount", currency.DailyAmount},
+			{"monthly_amount", currency.MonthlyAmount},
+			{"approval_threshold", currency.ApprovalThreshold},
+		} {
+			if limit.value < 0 {
+				errs.add(field+"."+limit.field, "must not be negative, use 0 for no limit")
+			}
+		}
+	}
+
+	if r.BeneficiaryPolicy != "" {
+		errs.oneOf("beneficiary_policy", r.BeneficiaryPolicy, "REJECT", "APPROVAL")
+	}
+	return errs
+}
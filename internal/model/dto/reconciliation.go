@@ -0,0 +1,9 @@
+package dto
+
+import "time"
+
+type ReconciliationRequest struct {
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+	SelfHeal bool      `json:"self_heal"`
+}
@@ -0,0 +1,36 @@
+package dto
+
+// SettlementTotal sums a tenant's report lines in one currency. Gross is
+// income, Payouts and Refunds are money sent out, and Net is Gross less every
+// other column.
+type SettlementTotal struct {
+	Currency string  `json:"currency"`
+	Count    int     `json:"count"`
+	Gross    float64 `json:"gross"`
+	Payouts  float64 `json:"payouts"`
+	Refunds  float64 `json:"refunds"`
+	Fees     float64 `json:"fees"`
+	Split    float64 `json:"split"`
+	Net      float64 `json:"net"`
+}
+
+type TenantSettlement struct {
+	TenantID   uint              `json:"tenant_id"`
+	TenantName string            `json:"tenant_name"`
+	Totals     []SettlementTotal `json:"totals"`
+}
+
+// UnmatchedSettlementLine is a report line that couldn't be tied to a broker
+// transaction, with Line counting the header as line 1.
+type UnmatchedSettlementLine struct {
+	Line      int    `json:"line"`
+	Reference string `json:"reference"`
+	Reason    string `json:"reason"`
+}
+
+type SettlementStatement struct {
+	Lines     int                       `json:"lines"`
+	Matched   int                       `json:"matched"`
+	Tenants   []TenantSettlement        `json:"tenants"`
+	Unmatched []UnmatchedSettlementLine `json:"unmatched"`
+}
@@ -0,0 +1,27 @@
+package dto
+
+type XenditSplitRuleRoute struct {
+	FlatAmount           *float64 `json:"flat_amount,omitempty"`
+	PercentAmount        *float64 `json:"percent_amount,omitempty"`
+	Currency             string   `json:"currency"`
+	DestinationAccountID string   `json:"destination_account_id"`
+	ReferenceID          string   `json:"reference_id"`
+}
+
+type XenditCreateSplitRule struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	Routes      []XenditSplitRuleRoute `json:"routes"`
+}
+
+type XenditSplitRule struct {
+	ID          string                 `json:"id"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Routes      []XenditSplitRuleRoute `json:"routes"`
+}
+
+type AttachSplitRule struct {
+	SplitRuleID string `json:"split_rule_id"`
+	Endpoint    string `json:"endpoint"`
+}
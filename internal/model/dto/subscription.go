@@ -0,0 +1,25 @@
+package dto
+
+import "time"
+
+type XenditRecurringPlan struct {
+	ID          string  `json:"id"`
+	ReferenceID string  `json:"reference_id"`
+	CustomerID  string  `json:"customer_id"`
+	Currency    string  `json:"currency"`
+	Amount      float64 `json:"amount"`
+	Status      string  `json:"status"`
+}
+
+type XenditRecurringCycle struct {
+	ID                 string     `json:"id"`
+	PlanID             string     `json:"plan_id"`
+	ReferenceID        string     `json:"reference_id"`
+	CycleNumber        int        `json:"cycle_number"`
+	AttemptCount       int        `json:"attempt_count"`
+	Currency           string     `json:"currency"`
+	Amount             float64    `json:"amount"`
+	Status             string     `json:"status"`
+	FailureCode        string     `json:"failure_code"`
+	ScheduledTimestamp *time.Time `json:"scheduled_timestamp"`
+}
@@ -1,6 +1,41 @@
 package dto
 
+import "fmt"
+
 type TenantCheckAPIKey struct {
 	ID        string
 	AccountID string
 }
+
+type TenantCurrenciesRequest struct {
+	AllowedCurrencies []string `json:"allowed_currencies"`
+	DefaultCurrency   string   `json:"default_currency"`
+}
+
+func (r *TenantCurrenciesRequest) Validate() ValidationErrors {
+	var errs ValidationErrors
+	seen := map[string]bool{}
+
+	for i, currency := range r.AllowedCurrencies {
+		field := fmt.Sprintf("allowed_currencies[%d]", i)
+		errs.currency(field, currency, nil)
+		if seen[currency] {
+			errs.add(field, "is listed twice")
+		}
+		seen[currency] = true
+	}
+
+	if r.DefaultCurrency != "" {
+		errs.currency("default_currency", r.DefaultCurrency, r.AllowedCurrencies)
+	}
+
+	return errs
+}
+
+// TenantCurrencies is a tenant's effective currency settings, with the
+// allowed list and default already filled in when the tenant left them empty.
+type TenantCurrencies struct {
+	TenantID          uint     `json:"tenant_id"`
+	AllowedCurrencies []string `json:"allowed_currencies"`
+	DefaultCurrency   string   `json:"default_currency"`
+}
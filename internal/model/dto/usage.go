@@ -0,0 +1,15 @@
+package dto
+
+type UsageSummary struct {
+	TenantID   uint   `json:"tenant_id"`
+	TenantName string `json:"tenant_name"`
+	Method     string `json:"method"`
+	Endpoint   string `json:"endpoint"`
+	Outcome    string `json:"outcome"`
+	Count      int64  `json:"count"`
+}
+
+type UsageExport struct {
+	Month string         `json:"month"`
+	Usage []UsageSummary `json:"usage"`
+}
@@ -0,0 +1,110 @@
+package dto
+
+import (
+	"fmt"
+	"payment-broker/internal/helper"
+	"strings"
+)
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+type ValidationErrors []FieldError
+
+// ActionRequest is a typed tenant request body that can check itself before it
+// is forwarded to Xendit.
+type ActionRequest interface {
+	Validate() ValidationErrors
+}
+
+// CurrencyRequest is an ActionRequest whose currency is limited to the tenant's
+// allowed currencies. ApplyTenantCurrencies must run before Validate, and it
+// returns the currency to forward, which is the tenant default when the body
+// has none.
+type CurrencyRequest interface {
+	ActionRequest
+	ApplyTenantCurrencies(allowed []string, defaultCurrency string) string
+}
+
+func (v *ValidationErrors) add(field, message string) {
+	*v = append(*v, FieldError{Field: field, Message: message})
+}
+
+// Add records an error found outside Validate, such as a value of the wrong
+// JSON type.
+func (v *ValidationErrors) Add(field, message string) {
+	v.add(field, message)
+}
+
+// Has reports whether field already has an error.
+func (v ValidationErrors) Has(field string) bool {
+	for _, fieldErr := range v {
+		if fieldErr.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *ValidationErrors) required(field, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.add(field, "is required")
+	}
+}
+
+func (v *ValidationErrors) amount(field string, amount float64, currency string) {
+	switch {
+	case amount <= 0:
+		v.add(field, "must be greater than 0")
+	case amount > helper.MaxAmount:
+		v.add(field, fmt.Sprintf("must not exceed %d", int64(helper.MaxAmount)))
+	case helper.IsZeroDecimalCurrency(currency) && !helper.IsWholeNumber(amount):
+		v.add(field, fmt.Sprintf("must be a whole number for %s", currency))
+	}
+}
+
+// currency checks against allowed, or every supported currency when allowed is empty.
+func (v *ValidationErrors) currency(field, currency string, allowed []string) {
+	if currency == "" {
+		v.add(field, "is required")
+		return
+	}
+
+	if !helper.IsAllowedCurrency(allowed, currency) {
+		if len(allowed) == 0 {
+			allowed = helper.SupportedCurrencies
+		}
+		v.add(field, fmt.Sprintf("must be one of %s", strings.Join(allowed, ", ")))
+	}
+}
+
+func (v *ValidationErrors) email(field, email string) {
+	if email != "" && !helper.IsEmail(email) {
+		v.add(field, "must be a valid email address")
+	}
+}
+
+func (v *ValidationErrors) phone(field, phone string) {
+	if phone != "" && !helper.IsPhone(phone) {
+		v.add(field, "must be an E.164 phone number, e.g. +6281234567890")
+	}
+}
+
+func (v *ValidationErrors) url(field, url string) {
+	if url == "" {
+		v.add(field, "is required")
+	} else if !helper.IsWebhookURL(url) {
+		v.add(field, "must be a valid URL starting with http:// or https://")
+	}
+}
+
+func (v *ValidationErrors) oneOf(field, value string, options ...string) {
+	for _, o := range options {
+		if value == o {
+			return
+		}
+	}
+	v.add(field, fmt.Sprintf("must be one of %s", strings.Join(options, ", ")))
+}
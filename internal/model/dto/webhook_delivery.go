@@ -0,0 +1,57 @@
+package dto
+
+import (
+	model "payment-broker/internal/model/db"
+	"time"
+)
+
+// WebhookTestEventTypes are the sample events an operator can send to a tenant.
+var WebhookTestEventTypes = []string{
+	"invoice.paid",
+	"payout.succeeded",
+	"payout.failed",
+	"recurring.cycle.succeeded",
+	"refund.succeeded",
+}
+
+// WebhookDeliveryFilter narrows a tenant's delivery attempts. Status is
+// succeeded, failed or an HTTP status code. Zero values don't filter.
+type WebhookDeliveryFilter struct {
+	EventType string
+	Status    string
+	From      time.Time
+	To        time.Time
+	BeforeID  uint
+	Limit     int
+}
+
+type WebhookTestRequest struct {
+	EventType string `json:"event_type"`
+}
+
+func (r *WebhookTestRequest) Validate() ValidationErrors {
+	var errs ValidationErrors
+	errs.oneOf("event_type", r.EventType, WebhookTestEventTypes...)
+	return errs
+}
+
+// WebhookBreaker is a breaker with the number of deliveries queued behind it
+// and the failed ordered deliveries to its URL.
+type WebhookBreaker struct {
+	model.WebhookBreaker
+	Queued int64          `json:"queued"`
+	Stuck  []StuckWebhook `json:"stuck"`
+}
+
+// StuckWebhook is an ordered delivery that failed and holds back the later
+// deliveries of its resource until it is sent, or an admin skips it.
+type StuckWebhook struct {
+	ID             uint       `json:"id"`
+	ResourceID     string     `json:"resource_id"`
+	EventID        string     `json:"event_id"`
+	Attempts       int        `json:"attempts"`
+	LastError      string     `json:"last_error"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	DeadLetteredAt *time.Time `json:"dead_lettered_at,omitempty"`
+	QueuedAt       time.Time  `json:"queued_at"`
+}
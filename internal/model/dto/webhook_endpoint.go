@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"fmt"
+	model "payment-broker/internal/model/db"
+	"regexp"
+)
+
+var eventPatternRegex = regexp.MustCompile(`^(\*|[a-z_]+(\.[a-z_]+)*(\.\*)?)$`)
+
+type WebhookEndpointRequest struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Format     string   `json:"format"`
+	Ordered    *bool    `json:"ordered"`
+	Active     *bool    `json:"active"`
+}
+
+func (r *WebhookEndpointRequest) Validate() ValidationErrors {
+	var errs ValidationErrors
+	errs.url("url", r.URL)
+
+	if len(r.EventTypes) == 0 {
+		errs.add("event_types", "must list at least one event type, use * for every event")
+	}
+	for i, eventType := range r.EventTypes {
+		if !eventPatternRegex.MatchString(eventType) {
+			errs.add(fmt.Sprintf("event_types[%d]", i), "must be an event type such as payout.failed, a pattern such as invoice.*, or *")
+		}
+	}
+
+	if r.Format != "" {
+		errs.oneOf("format", r.Format, model.WebhookFormatRaw, model.WebhookFormatEnvelopeV1)
+	}
+
+	return errs
+}
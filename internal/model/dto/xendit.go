@@ -1,5 +1,7 @@
 package dto
 
+import "encoding/json"
+
 type XenditResponse struct {
 	StatusCode int
 	Header     map[string][]string
@@ -13,3 +15,49 @@ type XenditNonEventWebhook struct {
 type XenditEventWebhook struct {
 	ReferenceID string `json:"reference_id"`
 }
+
+type XenditWebhookEvent struct {
+	Event string          `json:"event"`
+	Data  json.RawMessage `json:"data"`
+}
+
+type XenditAccountPublicProfile struct {
+	BusinessName string `json:"business_name"`
+}
+
+type XenditCreateAccount struct {
+	Email         string                     `json:"email"`
+	Type          string                     `json:"type"`
+	PublicProfile XenditAccountPublicProfile `json:"public_profile"`
+}
+
+type XenditAccount struct {
+	ID            string                     `json:"id"`
+	Type          string                     `json:"type"`
+	Email         string                     `json:"email"`
+	Status        string                     `json:"status"`
+	PublicProfile XenditAccountPublicProfile `json:"public_profile"`
+}
+
+type XenditAccountWebhook struct {
+	Event string        `json:"event"`
+	Data  XenditAccount `json:"data"`
+}
+
+type XenditTransaction struct {
+	ID          string  `json:"id"`
+	ProductID   string  `json:"product_id"`
+	ReferenceID string  `json:"reference_id"`
+	Status      string  `json:"status"`
+	Amount      float64 `json:"amount"`
+	Currency    string  `json:"currency"`
+}
+
+type XenditTransactionList struct {
+	Data    []XenditTransaction `json:"data"`
+	HasMore bool                `json:"has_more"`
+}
+
+type XenditBalance struct {
+	Balance float64 `json:"balance"`
+}
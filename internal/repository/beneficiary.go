@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	model "payment-broker/internal/model/db"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type BeneficiaryRepository interface {
+	Create(beneficiary *model.Beneficiary) error
+	Find(id uint) (*model.Beneficiary, error)
+	FindByTenant(tenantID uint) ([]model.Beneficiary, error)
+	FindByStatus(status string) ([]model.Beneficiary, error)
+	FindDestination(tenantID uint, channelCode, accountNumber string) (*model.Beneficiary, error)
+	UpdateStatus(id uint, from string, fields map[string]interface{}) error
+	Delete(tenantID uint, id uint) error
+}
+
+type beneficiaryRepository struct {
+	logger *zap.Logger
+	db     *gorm.DB
+}
+
+func NewBeneficiaryRepository(logger *zap.Logger, db *gorm.DB) BeneficiaryRepository {
+	return &beneficiaryRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+func (r *beneficiaryRepository) Create(beneficiary *model.Beneficiary) error {
+	return r.db.Create(beneficiary).Error
+}
+
+func (r *beneficiaryRepository) Find(id uint) (*model.Beneficiary, error) {
+	var beneficiary model.Beneficiary
+	err := r.db.First(&beneficiary, id).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("beneficiary not found")
+		}
+		r.logger.Error("beneficiaryRepository.Find", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	return &beneficiary, nil
+}
+
+func (r *beneficiaryRepository) FindByTenant(tenantID uint) ([]model.Beneficiary, error) {
+	var beneficiaries []model.Beneficiary
+	err := r.db.Where("tenant_id = ?", tenantID).Order("created_at").Find(&beneficiaries).Error
+	return beneficiaries, err
+}
+
+func (r *beneficiaryRepository) FindByStatus(status string) ([]model.Beneficiary, error) {
+	var beneficiaries []model.Beneficiary
+	query := r.db.Order("created_at")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Find(&beneficiaries).Error
+	return beneficiaries, err
+}
+
+// FindDestination returns nil without an error when the destination isn't registered.
+func (r *beneficiaryRepository) FindDestination(tenantID uint, channelCode, accountNumber string) (*model.Beneficiary, error) {
+	var beneficiary model.Beneficiary
+	err := r.db.Where("tenant_id = ? AND channel_code = ? AND account_number = ?", tenantID, channelCode, accountNumber).
+		First(&beneficiary).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.logger.Error("beneficiaryRepository.FindDestination", zap.Uint("tenant_id", tenantID), zap.Error(err))
+		return nil, err
+	}
+
+	return &beneficiary, nil
+}
+
+func (r *beneficiaryRepository) UpdateStatus(id uint, from string, fields map[string]interface{}) error {
+	res := r.db.Model(&model.Beneficiary{}).Where("id = ? AND status = ?", id, from).Updates(fields)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("beneficiary is no longer %s", from)
+	}
+	return nil
+}
+
+func (r *beneficiaryRepository) Delete(tenantID uint, id uint) error {
+	res := r.db.Where("tenant_id = ? AND id = ?", tenantID, id).Delete(&model.Beneficiary{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("beneficiary not found")
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	model "payment-broker/internal/model/db"
+	"payment-broker/internal/model/dto"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type EventRepository interface {
+	Create(event *model.TenantEvent) error
+	FindByTenant(tenantID uint, filter dto.EventFilter, settled time.Time) ([]model.TenantEvent, error)
+	Acknowledge(tenantID uint, ids []uint, at time.Time) (int64, error)
+	FindLatestID(tenantID uint, settled time.Time) (uint, error)
+	DeleteBefore(before time.Time) (int64, error)
+	PublishPending(limit int, publish func(events []model.TenantEvent) []uint) (int, error)
+}
+
+type eventRepository struct {
+	logger *zap.Logger
+	db     *gorm.DB
+}
+
+func NewEventRepository(logger *zap.Logger, db *gorm.DB) EventRepository {
+	return &eventRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+// Create stores the event unless the tenant already has one with its EventID.
+func (r *eventRepository) Create(event *model.TenantEvent) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tenant_id"}, {Name: "event_id"}},
+		DoNothing: true,
+	}).Create(event).Error
+}
+
+// FindByTenant returns the tenant's oldest events after filter.After that were
+// created by settled. IDs are taken before the insert commits, so a lower ID
+// can become visible after a higher one; leaving out recent rows keeps a
+// reader from moving its cursor past one that is still committing.
+func (r *eventRepository) FindByTenant(tenantID uint, filter dto.EventFilter, settled time.Time) ([]model.TenantEvent, error) {
+	query := r.db.Where("tenant_id = ? AND id > ? AND created_at <= ?", tenantID, filter.After, settled)
+	if filter.Pending {
+		query = query.Where("acknowledged_at IS NULL")
+	}
+
+	var events []model.TenantEvent
+	err := query.Order("id").Limit(filter.Limit).Find(&events).Error
+	return events, err
+}
+
+// Acknowledge marks the tenant's events with the given IDs consumed, keeping
+// the first acknowledgement time, and returns how many it newly marked.
+func (r *eventRepository) Acknowledge(tenantID uint, ids []uint, at time.Time) (int64, error) {
+	res := r.db.Model(&model.TenantEvent{}).
+		Where("tenant_id = ? AND id IN ? AND acknowledged_at IS NULL", tenantID, ids).
+		Update("acknowledged_at", at)
+	return res.RowsAffected, res.Error
+}
+
+// FindLatestID returns the ID of the tenant's newest event created by settled,
+// for the same reason as FindByTenant.
+func (r *eventRepository) FindLatestID(tenantID uint, settled time.Time) (uint, error) {
+	var id uint
+	err := r.db.Model(&model.TenantEvent{}).Select("COALESCE(MAX(id), 0)").
+		Where("tenant_id = ? AND created_at <= ?", tenantID, settled).Scan(&id).Error
+	return id, err
+}
+
+func (r *eventRepository) DeleteBefore(before time.Time) (int64, error) {
+	res := r.db.Where("created_at < ?", before).Delete(&model.TenantEvent{})
+	return res.RowsAffected, res.Error
+}
+
+// PublishPending locks up to limit unpublished events, oldest first, and hands
+// them to publish, which returns the IDs it published. Those are marked
+// published in the same transaction, so relays on other instances skip the
+// events locked here instead of publishing them again.
+func (r *eventRepository) PublishPending(limit int, publish func(events []model.TenantEvent) []uint) (int, error) {
+	published := 0
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var events []model.TenantEvent
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("unpublished").Order("id").Limit(limit).Find(&events).Error
+		if err != nil || len(events) == 0 {
+			return err
+		}
+
+		ids := publish(events)
+		if len(ids) == 0 {
+			return nil
+		}
+
+		published = len(ids)
+		return tx.Model(&model.TenantEvent{}).Where("id IN ?", ids).Update("unpublished", false).Error
+	})
+	return published, err
+}
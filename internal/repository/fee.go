@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"errors"
+	model "payment-broker/internal/model/db"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FeeRepository interface {
+	FindRules(tenantID uint) ([]model.FeeRule, error)
+	ReplaceRules(tenantID uint, rules []model.FeeRule) error
+	CreateFee(fee *model.PlatformFee) error
+	FindInvoiceFee(invoiceID string) (*model.PlatformFee, error)
+	FindFees(tenantID uint, from, to time.Time) ([]model.PlatformFee, error)
+}
+
+type feeRepository struct {
+	logger *zap.Logger
+	db     *gorm.DB
+}
+
+func NewFeeRepository(logger *zap.Logger, db *gorm.DB) FeeRepository {
+	return &feeRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+func (r *feeRepository) FindRules(tenantID uint) ([]model.FeeRule, error) {
+	var rules []model.FeeRule
+	err := r.db.Where("tenant_id = ?", tenantID).Order("payment_method, currency").Find(&rules).Error
+	return rules, err
+}
+
+func (r *feeRepository) ReplaceRules(tenantID uint, rules []model.FeeRule) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("tenant_id = ?", tenantID).Delete(&model.FeeRule{}).Error; err != nil {
+			return err
+		}
+		if len(rules) == 0 {
+			return nil
+		}
+		return tx.Create(&rules).Error
+	})
+}
+
+// CreateFee ignores fees already recorded for the invoice, since Xendit may
+// deliver the same callback more than once.
+func (r *feeRepository) CreateFee(fee *model.PlatformFee) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "invoice_id"}},
+		DoNothing: true,
+	}).Create(fee).Error
+}
+
+// FindInvoiceFee returns nil without an error when no fee was recorded for the invoice.
+func (r *feeRepository) FindInvoiceFee(invoiceID string) (*model.PlatformFee, error) {
+	var fee model.PlatformFee
+	err := r.db.Where("invoice_id = ?", invoiceID).First(&fee).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &fee, nil
+}
+
+func (r *feeRepository) FindFees(tenantID uint, from, to time.Time) ([]model.PlatformFee, error) {
+	var fees []model.PlatformFee
+	err := r.db.Where("tenant_id = ? AND paid_at >= ? AND paid_at < ?", tenantID, from, to).
+		Order("paid_at").Find(&fees).Error
+	return fees, err
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"errors"
+	model "payment-broker/internal/model/db"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type InvoiceRepository interface {
+	Upsert(invoice *model.Invoice) error
+	Find(tenantID uint, invoiceID string) (*model.Invoice, error)
+	HasReference(tenantID uint, externalID string) (bool, error)
+}
+
+type invoiceRepository struct {
+	logger *zap.Logger
+	db     *gorm.DB
+}
+
+func NewInvoiceRepository(logger *zap.Logger, db *gorm.DB) InvoiceRepository {
+	return &invoiceRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+func (r *invoiceRepository) Upsert(invoice *model.Invoice) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "invoice_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"status", "payment_method", "payment_channel", "paid_at", "updated_at"}),
+	}).Create(invoice).Error
+}
+
+// Find returns nil without an error when the invoice isn't stored.
+func (r *invoiceRepository) Find(tenantID uint, invoiceID string) (*model.Invoice, error) {
+	var invoice model.Invoice
+	err := r.db.Where("tenant_id = ? AND invoice_id = ?", tenantID, invoiceID).First(&invoice).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.logger.Error("invoiceRepository.Find", zap.Uint("tenant_id", tenantID), zap.String("invoice_id", invoiceID), zap.Error(err))
+		return nil, err
+	}
+
+	return &invoice, nil
+}
+
+func (r *invoiceRepository) HasReference(tenantID uint, externalID string) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.Invoice{}).Where("tenant_id = ? AND external_id = ?", tenantID, externalID).Count(&count).Error
+	return count > 0, err
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	model "payment-broker/internal/model/db"
+	"payment-broker/internal/model/dto"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LedgerRepository interface {
+	CreateEntry(entry *model.LedgerEntry) (bool, error)
+	HasEntry(key string) (bool, error)
+	FindTotals(tenantID uint) ([]dto.LedgerAccountTotal, error)
+	FindEntries(tenantID uint, beforeID uint, limit int) ([]model.LedgerEntry, error)
+}
+
+type ledgerRepository struct {
+	logger *zap.Logger
+	db     *gorm.DB
+}
+
+func NewLedgerRepository(logger *zap.Logger, db *gorm.DB) LedgerRepository {
+	return &ledgerRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+// CreateEntry stores the entry with its lines and reports false when an entry
+// with the same key was already posted. The insert skips conflicting keys
+// instead of checking first, so concurrent duplicates don't hit the unique index.
+func (r *ledgerRepository) CreateEntry(entry *model.LedgerEntry) (bool, error) {
+	created := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		lines := entry.Lines
+		result := tx.Omit("Lines").
+			Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "key"}}, DoNothing: true}).
+			Create(entry)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		created = true
+		if len(lines) == 0 {
+			return nil
+		}
+		for i := range lines {
+			lines[i].EntryID = entry.ID
+		}
+		return tx.Create(&lines).Error
+	})
+	return created, err
+}
+
+func (r *ledgerRepository) HasEntry(key string) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.LedgerEntry{}).Where("key = ?", key).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *ledgerRepository) FindTotals(tenantID uint) ([]dto.LedgerAccountTotal, error) {
+	var totals []dto.LedgerAccountTotal
+	err := r.db.Model(&model.LedgerLine{}).
+		Select("account, currency, SUM(debit) AS debit, SUM(credit) AS credit").
+		Where("tenant_id = ?", tenantID).
+		Group("account, currency").
+		Order("currency, account").
+		Scan(&totals).Error
+	return totals, err
+}
+
+// FindEntries returns the newest entries first, starting below beforeID when
+// it isn't zero.
+func (r *ledgerRepository) FindEntries(tenantID uint, beforeID uint, limit int) ([]model.LedgerEntry, error) {
+	query := r.db.Preload("Lines").Where("tenant_id = ?", tenantID)
+	if beforeID > 0 {
+		query = query.Where("id < ?", beforeID)
+	}
+
+	var entries []model.LedgerEntry
+	err := query.Order("id DESC").Limit(limit).Find(&entries).Error
+	return entries, err
+}
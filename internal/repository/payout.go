@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	model "payment-broker/internal/model/db"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PayoutRepository interface {
+	Upsert(payout *model.Payout) error
+	Find(tenantID uint, payoutID string) (*model.Payout, error)
+	FindIfExists(tenantID uint, payoutID string) (*model.Payout, error)
+	HasReference(tenantID uint, referenceID string) (bool, error)
+}
+
+type payoutRepository struct {
+	logger *zap.Logger
+	db     *gorm.DB
+}
+
+func NewPayoutRepository(logger *zap.Logger, db *gorm.DB) PayoutRepository {
+	return &payoutRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+func (r *payoutRepository) Upsert(payout *model.Payout) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "payout_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"status", "failure_code", "updated_at"}),
+	}).Create(payout).Error
+}
+
+func (r *payoutRepository) Find(tenantID uint, payoutID string) (*model.Payout, error) {
+	var payout model.Payout
+	err := r.db.Where("tenant_id = ? AND payout_id = ?", tenantID, payoutID).First(&payout).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			r.logger.Debug("payoutRepository.Find", zap.Uint("tenant_id", tenantID), zap.String("payout_id", payoutID))
+			return nil, fmt.Errorf("payout not found")
+		}
+		r.logger.Error("payoutRepository.Find", zap.Uint("tenant_id", tenantID), zap.String("payout_id", payoutID), zap.Error(err))
+		return nil, err
+	}
+
+	return &payout, nil
+}
+
+// FindIfExists returns nil without an error when the payout isn't stored.
+func (r *payoutRepository) FindIfExists(tenantID uint, payoutID string) (*model.Payout, error) {
+	var payout model.Payout
+	err := r.db.Where("tenant_id = ? AND payout_id = ?", tenantID, payoutID).First(&payout).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &payout, nil
+}
+
+func (r *payoutRepository) HasReference(tenantID uint, referenceID string) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.Payout{}).Where("tenant_id = ? AND reference_id = ?", tenantID, referenceID).Count(&count).Error
+	return count > 0, err
+}
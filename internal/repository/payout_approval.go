@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	model "payment-broker/internal/model/db"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type PayoutApprovalRepository interface {
+	Create(approval *model.PayoutApproval, event *model.PayoutApprovalEvent) error
+	Find(id uint) (*model.PayoutApproval, error)
+	FindForTenant(tenantID uint, id uint) (*model.PayoutApproval, error)
+	FindByStatus(status string) ([]model.PayoutApproval, error)
+	Transition(id uint, from string, to string, fields map[string]interface{}, event *model.PayoutApprovalEvent) error
+	FindEvents(approvalID uint) ([]model.PayoutApprovalEvent, error)
+}
+
+type payoutApprovalRepository struct {
+	logger *zap.Logger
+	db     *gorm.DB
+}
+
+func NewPayoutApprovalRepository(logger *zap.Logger, db *gorm.DB) PayoutApprovalRepository {
+	return &payoutApprovalRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+func (r *payoutApprovalRepository) Create(approval *model.PayoutApproval, event *model.PayoutApprovalEvent) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(approval).Error; err != nil {
+			return err
+		}
+		event.ApprovalID = approval.ID
+		return tx.Create(event).Error
+	})
+}
+
+func (r *payoutApprovalRepository) Find(id uint) (*model.PayoutApproval, error) {
+	var approval model.PayoutApproval
+	err := r.db.First(&approval, id).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("payout approval not found")
+		}
+		r.logger.Error("payoutApprovalRepository.Find", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	return &approval, nil
+}
+
+func (r *payoutApprovalRepository) FindForTenant(tenantID uint, id uint) (*model.PayoutApproval, error) {
+	var approval model.PayoutApproval
+	err := r.db.Where("tenant_id = ? AND id = ?", tenantID, id).First(&approval).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("payout approval not found")
+		}
+		r.logger.Error("payoutApprovalRepository.FindForTenant", zap.Uint("tenant_id", tenantID), zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	return &approval, nil
+}
+
+func (r *payoutApprovalRepository) FindByStatus(status string) ([]model.PayoutApproval, error) {
+	var approvals []model.PayoutApproval
+	query := r.db.Order("created_at")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Find(&approvals).Error
+	return approvals, err
+}
+
+// Transition moves an approval between states only if it is still in the
+// expected state, so two reviewers can't act on the same payout.
+func (r *payoutApprovalRepository) Transition(id uint, from string, to string, fields map[string]interface{}, event *model.PayoutApprovalEvent) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		updates := map[string]interface{}{"status": to}
+		for k, v := range fields {
+			updates[k] = v
+		}
+
+		res := tx.Model(&model.PayoutApproval{}).Where("id = ? AND status = ?", id, from).Updates(updates)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return fmt.Errorf("payout approval is no longer %s", from)
+		}
+
+		event.ApprovalID = id
+		return tx.Create(event).Error
+	})
+}
+
+func (r *payoutApprovalRepository) FindEvents(approvalID uint) ([]model.PayoutApprovalEvent, error) {
+	var events []model.PayoutApprovalEvent
+	err := r.db.Where("approval_id = ?", approvalID).Order("id").Find(&events).Error
+	return events, err
+}
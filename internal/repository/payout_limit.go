@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"errors"
+	model "payment-broker/internal/model/db"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PayoutLimitRepository interface {
+	Upsert(limit *model.PayoutLimit) error
+	Find(tenantID uint) (*model.PayoutLimit, error)
+}
+
+type payoutLimitRepository struct {
+	logger *zap.Logger
+	db     *gorm.DB
+}
+
+func NewPayoutLimitRepository(logger *zap.Logger, db *gorm.DB) PayoutLimitRepository {
+	return &payoutLimitRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+func (r *payoutLimitRepository) Upsert(limit *model.PayoutLimit) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tenant_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"hourly_count", "allowed_channels", "currencies", "beneficiary_policy", "updated_at"}),
+	}).Create(limit).Error
+}
+
+// Find returns nil without an error when the tenant has no limits configured.
+func (r *payoutLimitRepository) Find(tenantID uint) (*model.PayoutLimit, error) {
+	var limit model.PayoutLimit
+	err := r.db.Where("tenant_id = ?", tenantID).First(&limit).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.logger.Error("payoutLimitRepository.Find", zap.Uint("tenant_id", tenantID), zap.Error(err))
+		return nil, err
+	}
+
+	return &limit, nil
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	model "payment-broker/internal/model/db"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type ReconciliationRepository interface {
+	CreateRun(run *model.ReconciliationRun) error
+	SaveRun(run *model.ReconciliationRun) error
+	CreateMismatch(mismatch *model.ReconciliationMismatch) error
+	FindRuns(limit int) ([]model.ReconciliationRun, error)
+	FindRun(id uint) (*model.ReconciliationRun, error)
+	FindMismatches(runID uint) ([]model.ReconciliationMismatch, error)
+}
+
+type reconciliationRepository struct {
+	logger *zap.Logger
+	db     *gorm.DB
+}
+
+func NewReconciliationRepository(logger *zap.Logger, db *gorm.DB) ReconciliationRepository {
+	return &reconciliationRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+func (r *reconciliationRepository) CreateRun(run *model.ReconciliationRun) error {
+	return r.db.Create(run).Error
+}
+
+func (r *reconciliationRepository) SaveRun(run *model.ReconciliationRun) error {
+	return r.db.Save(run).Error
+}
+
+func (r *reconciliationRepository) CreateMismatch(mismatch *model.ReconciliationMismatch) error {
+	return r.db.Create(mismatch).Error
+}
+
+func (r *reconciliationRepository) FindRuns(limit int) ([]model.ReconciliationRun, error) {
+	var runs []model.ReconciliationRun
+	err := r.db.Order("id DESC").Limit(limit).Find(&runs).Error
+	return runs, err
+}
+
+func (r *reconciliationRepository) FindRun(id uint) (*model.ReconciliationRun, error) {
+	var run model.ReconciliationRun
+	err := r.db.First(&run, id).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("reconciliation run not found")
+		}
+		r.logger.Error("reconciliationRepository.FindRun", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	return &run, nil
+}
+
+func (r *reconciliationRepository) FindMismatches(runID uint) ([]model.ReconciliationMismatch, error) {
+	var mismatches []model.ReconciliationMismatch
+	err := r.db.Where("run_id = ?", runID).Order("tenant_id, id").Find(&mismatches).Error
+	return mismatches, err
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"errors"
+	model "payment-broker/internal/model/db"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SplitRuleRepository interface {
+	Upsert(rule *model.SplitRule) error
+	FindByTenant(tenantID uint) ([]model.SplitRule, error)
+	Find(tenantID uint, endpoint string) (*model.SplitRule, error)
+	Delete(tenantID uint, endpoint string) error
+}
+
+type splitRuleRepository struct {
+	logger *zap.Logger
+	db     *gorm.DB
+}
+
+func NewSplitRuleRepository(logger *zap.Logger, db *gorm.DB) SplitRuleRepository {
+	return &splitRuleRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+func (r *splitRuleRepository) Upsert(rule *model.SplitRule) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tenant_id"}, {Name: "endpoint"}},
+		DoUpdates: clause.AssignmentColumns([]string{"xendit_split_rule_id", "updated_at"}),
+	}).Create(rule).Error
+}
+
+func (r *splitRuleRepository) FindByTenant(tenantID uint) ([]model.SplitRule, error) {
+	var rules []model.SplitRule
+	err := r.db.Where("tenant_id = ?", tenantID).Order("endpoint").Find(&rules).Error
+	return rules, err
+}
+
+// Find returns nil without an error when the tenant has no rule for the endpoint.
+func (r *splitRuleRepository) Find(tenantID uint, endpoint string) (*model.SplitRule, error) {
+	var rule model.SplitRule
+	err := r.db.Where("tenant_id = ? AND endpoint = ?", tenantID, endpoint).First(&rule).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.logger.Error("splitRuleRepository.Find", zap.Uint("tenant_id", tenantID), zap.String("endpoint", endpoint), zap.Error(err))
+		return nil, err
+	}
+
+	return &rule, nil
+}
+
+func (r *splitRuleRepository) Delete(tenantID uint, endpoint string) error {
+	return r.db.Where("tenant_id = ? AND endpoint = ?", tenantID, endpoint).Delete(&model.SplitRule{}).Error
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	model "payment-broker/internal/model/db"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SubscriptionRepository interface {
+	UpsertPlan(plan *model.SubscriptionPlan) error
+	FindPlans(tenantID uint, status string) ([]model.SubscriptionPlan, error)
+	FindPlan(tenantID uint, planID string) (*model.SubscriptionPlan, error)
+	UpsertCycle(cycle *model.SubscriptionCycle) error
+	FindCycles(tenantID uint, planID string) ([]model.SubscriptionCycle, error)
+}
+
+type subscriptionRepository struct {
+	logger *zap.Logger
+	db     *gorm.DB
+}
+
+func NewSubscriptionRepository(logger *zap.Logger, db *gorm.DB) SubscriptionRepository {
+	return &subscriptionRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+func (r *subscriptionRepository) UpsertPlan(plan *model.SubscriptionPlan) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "plan_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"reference_id", "customer_id", "currency", "amount", "status", "updated_at"}),
+	}).Create(plan).Error
+}
+
+func (r *subscriptionRepository) FindPlans(tenantID uint, status string) ([]model.SubscriptionPlan, error) {
+	var plans []model.SubscriptionPlan
+	query := r.db.Where("tenant_id = ?", tenantID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Order("created_at DESC").Find(&plans).Error
+	return plans, err
+}
+
+func (r *subscriptionRepository) FindPlan(tenantID uint, planID string) (*model.SubscriptionPlan, error) {
+	var plan model.SubscriptionPlan
+	err := r.db.Where("tenant_id = ? AND plan_id = ?", tenantID, planID).First(&plan).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			r.logger.Debug("subscriptionRepository.FindPlan", zap.Uint("tenant_id", tenantID), zap.String("plan_id", planID))
+			return nil, fmt.Errorf("plan not found")
+		}
+		r.logger.Error("subscriptionRepository.FindPlan", zap.Uint("tenant_id", tenantID), zap.String("plan_id", planID), zap.Error(err))
+		return nil, err
+	}
+
+	return &plan, nil
+}
+
+func (r *subscriptionRepository) UpsertCycle(cycle *model.SubscriptionCycle) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "cycle_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"attempt_count", "currency", "amount", "status", "failure_code", "scheduled_at", "updated_at"}),
+	}).Create(cycle).Error
+}
+
+func (r *subscriptionRepository) FindCycles(tenantID uint, planID string) ([]model.SubscriptionCycle, error) {
+	var cycles []model.SubscriptionCycle
+	err := r.db.Where("tenant_id = ? AND plan_id = ?", tenantID, planID).Order("cycle_number").Find(&cycles).Error
+	return cycles, err
+}
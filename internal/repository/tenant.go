@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"errors"
 	"fmt"
 	model "payment-broker/internal/model/db"
 
@@ -14,6 +15,10 @@ type TenantRepository interface {
 	Create(tenant *model.Tenant) error
 	FindAll() ([]model.Tenant, error)
 	FindByID(id uint) (*model.Tenant, error)
+	FindIfExists(id uint) (*model.Tenant, error)
+	FindByAccountID(accountID string) (*model.Tenant, error)
+	UpdateAccountStatus(accountID, status string) (bool, error)
+	UpdateCurrencies(id uint, allowedCurrencies, defaultCurrency string) error
 	Delete(id uint) error
 }
 
@@ -48,6 +53,47 @@ func (r *tenantRepository) FindByID(id uint) (*model.Tenant, error) {
 	return &tenant, nil
 }
 
+// FindIfExists returns nil without an error when there is no such tenant.
+func (r *tenantRepository) FindIfExists(id uint) (*model.Tenant, error) {
+	var tenant model.Tenant
+	err := r.db.First(&tenant, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &tenant, nil
+}
+
+func (r *tenantRepository) FindByAccountID(accountID string) (*model.Tenant, error) {
+	var tenant model.Tenant
+	err := r.db.Where("account_id = ?", accountID).First(&tenant).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tenant, nil
+}
+
+// UpdateAccountStatus reports false without an error when no tenant has the
+// account.
+func (r *tenantRepository) UpdateAccountStatus(accountID, status string) (bool, error) {
+	res := r.db.Model(&model.Tenant{}).Where("account_id = ?", accountID).Update("account_status", status)
+	if res.Error != nil {
+		r.logger.Error("tenantRepository.UpdateAccountStatus", zap.String("account_id", accountID), zap.Error(res.Error))
+		return false, res.Error
+	}
+
+	return res.RowsAffected > 0, nil
+}
+
+func (r *tenantRepository) UpdateCurrencies(id uint, allowedCurrencies, defaultCurrency string) error {
+	return r.db.Model(&model.Tenant{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"allowed_currencies": allowedCurrencies,
+		"default_currency":   defaultCurrency,
+	}).Error
+}
+
 func (r *tenantRepository) Delete(id uint) error {
 	return r.db.Delete(&model.Tenant{}, id).Error
 }
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	model "payment-broker/internal/model/db"
+	"payment-broker/internal/model/dto"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type UsageRepository interface {
+	Increment(record *model.UsageRecord) error
+	Summarize(from, to time.Time) ([]dto.UsageSummary, error)
+}
+
+type usageRepository struct {
+	logger *zap.Logger
+	db     *gorm.DB
+}
+
+func NewUsageRepository(logger *zap.Logger, db *gorm.DB) UsageRepository {
+	return &usageRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+// Increment adds the record's count to its bucket, creating the bucket on the
+// first call of the day.
+func (r *usageRepository) Increment(record *model.UsageRecord) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "tenant_id"}, {Name: "day"}, {Name: "method"}, {Name: "endpoint"}, {Name: "outcome"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"count":      gorm.Expr("usage_records.count + EXCLUDED.count"),
+			"updated_at": gorm.Expr("EXCLUDED.updated_at"),
+		}),
+	}).Create(record).Error
+}
+
+// Summarize totals the daily buckets between from and to per tenant, endpoint
+// and outcome.
+func (r *usageRepository) Summarize(from, to time.Time) ([]dto.UsageSummary, error) {
+	var summaries []dto.UsageSummary
+	err := r.db.Model(&model.UsageRecord{}).
+		Select("usage_records.tenant_id, tenants.name AS tenant_name, usage_records.method, usage_records.endpoint, usage_records.outcome, SUM(usage_records.count) AS count").
+		Joins("LEFT JOIN tenants ON tenants.id = usage_records.tenant_id").
+		Where("usage_records.day >= ? AND usage_records.day < ?", from, to).
+		Group("usage_records.tenant_id, tenants.name, usage_records.method, usage_records.endpoint, usage_records.outcome").
+		Order("usage_records.tenant_id, usage_records.endpoint, usage_records.method, usage_records.outcome").
+		Scan(&summaries).Error
+	return summaries, err
+}
@@ -0,0 +1,282 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	model "payment-broker/internal/model/db"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookBreakerRepository interface {
+	FindIfExists(tenantID uint, url string) (*model.WebhookBreaker, error)
+	FindByTenant(tenantID uint) ([]model.WebhookBreaker, error)
+	FindDue(now time.Time) ([]model.WebhookBreaker, error)
+	AddFailure(tenantID uint, url string, lastError string) (*model.WebhookBreaker, error)
+	ResetFailures(id uint) error
+	Transition(id uint, from string, to string, fields map[string]interface{}) (bool, error)
+	Claim(breaker *model.WebhookBreaker, until time.Time) (bool, error)
+	Extend(id uint, until time.Time) error
+	Enqueue(item *model.QueuedWebhook) error
+	FindNextQueued(breakerID uint) (*model.QueuedWebhook, error)
+	DeleteQueued(id uint) error
+	CountQueued(breakerID uint) (int64, error)
+	FindOrderedHeads(now time.Time) ([]model.QueuedWebhook, error)
+	FindNextOrdered(item *model.QueuedWebhook) (*model.QueuedWebhook, error)
+	ClaimQueued(item *model.QueuedWebhook, until time.Time) (bool, error)
+	RetryQueued(id uint, attempts int, lastError string, at time.Time) error
+	DeadLetterQueued(id uint, attempts int, lastError string) error
+	FindStuckOrdered(tenantID uint) ([]model.QueuedWebhook, error)
+	SkipOrdered(tenantID uint, id uint) (bool, error)
+	ResumeOrdered(tenantID uint, id uint) (bool, error)
+}
+
+type webhookBreakerRepository struct {
+	logger *zap.Logger
+	db     *gorm.DB
+}
+
+func NewWebhookBreakerRepository(logger *zap.Logger, db *gorm.DB) WebhookBreakerRepository {
+	return &webhookBreakerRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+func (r *webhookBreakerRepository) FindIfExists(tenantID uint, url string) (*model.WebhookBreaker, error) {
+	var breaker model.WebhookBreaker
+	err := r.db.Where("tenant_id = ? AND url = ?", tenantID, url).First(&breaker).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.logger.Error("webhookBreakerRepository.FindIfExists", zap.Uint("tenant_id", tenantID), zap.Error(err))
+		return nil, err
+	}
+
+	return &breaker, nil
+}
+
+func (r *webhookBreakerRepository) FindByTenant(tenantID uint) ([]model.WebhookBreaker, error) {
+	var breakers []model.WebhookBreaker
+	err := r.db.Where("tenant_id = ?", tenantID).Order("id").Find(&breakers).Error
+	return breakers, err
+}
+
+// FindDue returns the open breakers whose probe time has come, the half-open
+// ones whose worker stopped renewing its claim, and the closed ones still
+// holding deliveries queued while they closed.
+func (r *webhookBreakerRepository) FindDue(now time.Time) ([]model.WebhookBreaker, error) {
+	var breakers []model.WebhookBreaker
+	err := r.db.Where("(state IN ? AND next_probe_at <= ?) OR (state = ? AND EXISTS (?))",
+		[]string{model.BreakerOpen, model.BreakerHalfOpen}, now, model.BreakerClosed,
+		r.db.Model(&model.QueuedWebhook{}).Select("1").Where("queued_webhooks.breaker_id = webhook_breakers.id"),
+	).Order("id").Find(&breakers).Error
+	return breakers, err
+}
+
+// AddFailure counts a failed delivery against the URL's breaker, creating the
+// breaker on its first failure, and returns it as it is afterwards.
+func (r *webhookBreakerRepository) AddFailure(tenantID uint, url string, lastError string) (*model.WebhookBreaker, error) {
+	breaker := model.WebhookBreaker{
+		TenantID:  tenantID,
+		URL:       url,
+		State:     model.BreakerClosed,
+		Failures:  1,
+		LastError: lastError,
+	}
+
+	err := r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "tenant_id"}, {Name: "url"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"failures":   gorm.Expr("webhook_breakers.failures + 1"),
+			"last_error": lastError,
+			"updated_at": time.Now(),
+		}),
+	}).Create(&breaker).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.db.Where("tenant_id = ? AND url = ?", tenantID, url).First(&breaker).Error; err != nil {
+		return nil, err
+	}
+	return &breaker, nil
+}
+
+func (r *webhookBreakerRepository) ResetFailures(id uint) error {
+	return r.db.Model(&model.WebhookBreaker{}).
+		Where("id = ? AND state = ?", id, model.BreakerClosed).
+		Updates(map[string]interface{}{"failures": 0, "last_error": ""}).Error
+}
+
+// Transition moves a breaker between states only if it is still in the
+// expected state.
+func (r *webhookBreakerRepository) Transition(id uint, from string, to string, fields map[string]interface{}) (bool, error) {
+	updates := map[string]interface{}{"state": to}
+	for k, v := range fields {
+		updates[k] = v
+	}
+
+	res := r.db.Model(&model.WebhookBreaker{}).Where("id = ? AND state = ?", id, from).Updates(updates)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+// Claim moves the breaker to HALF_OPEN until the given time, only if nobody
+// changed it since it was read, so one worker at a time drains it.
+func (r *webhookBreakerRepository) Claim(breaker *model.WebhookBreaker, until time.Time) (bool, error) {
+	query := r.db.Model(&model.WebhookBreaker{}).Where("id = ? AND state = ?", breaker.ID, breaker.State)
+	if breaker.NextProbeAt == nil {
+		query = query.Where("next_probe_at IS NULL")
+	} else {
+		query = query.Where("next_probe_at = ?", *breaker.NextProbeAt)
+	}
+
+	res := query.Updates(map[string]interface{}{"state": model.BreakerHalfOpen, "next_probe_at": until})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+func (r *webhookBreakerRepository) Extend(id uint, until time.Time) error {
+	return r.db.Model(&model.WebhookBreaker{}).
+		Where("id = ? AND state = ?", id, model.BreakerHalfOpen).
+		Update("next_probe_at", until).Error
+}
+
+func (r *webhookBreakerRepository) Enqueue(item *model.QueuedWebhook) error {
+	return r.db.Create(item).Error
+}
+
+func (r *webhookBreakerRepository) FindNextQueued(breakerID uint) (*model.QueuedWebhook, error) {
+	var item model.QueuedWebhook
+	err := r.db.Where("breaker_id = ?", breakerID).Order("id").First(&item).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.logger.Error("webhookBreakerRepository.FindNextQueued", zap.Uint("breaker_id", breakerID), zap.Error(err))
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+func (r *webhookBreakerRepository) DeleteQueued(id uint) error {
+	res := r.db.Delete(&model.QueuedWebhook{}, id)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("queued webhook not found")
+	}
+	return nil
+}
+
+func (r *webhookBreakerRepository) CountQueued(breakerID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.QueuedWebhook{}).Where("breaker_id = ?", breakerID).Count(&count).Error
+	return count, err
+}
+
+// FindOrderedHeads returns the oldest ordered delivery of each tenant, URL and
+// resource, when it is ready to be tried. A dead-lettered head is never ready,
+// so its resource waits for an admin.
+func (r *webhookBreakerRepository) FindOrderedHeads(now time.Time) ([]model.QueuedWebhook, error) {
+	heads := r.db.Model(&model.QueuedWebhook{}).Select("MIN(id)").
+		Where("breaker_id = 0").
+		Group("tenant_id, url, resource_id")
+
+	var items []model.QueuedWebhook
+	err := r.db.Where("id IN (?)", heads).
+		Where("dead_lettered_at IS NULL").
+		Where("next_attempt_at IS NULL OR next_attempt_at <= ?", now).
+		Order("id").Find(&items).Error
+	return items, err
+}
+
+// FindNextOrdered returns the ordered delivery queued after item for the same
+// tenant, URL and resource, or nil when there is none.
+func (r *webhookBreakerRepository) FindNextOrdered(item *model.QueuedWebhook) (*model.QueuedWebhook, error) {
+	var next model.QueuedWebhook
+	err := r.db.Where("breaker_id = 0 AND tenant_id = ? AND url = ? AND resource_id = ? AND id > ?",
+		item.TenantID, item.URL, item.ResourceID, item.ID).
+		Order("id").First(&next).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.logger.Error("webhookBreakerRepository.FindNextOrdered", zap.Uint("id", item.ID), zap.Error(err))
+		return nil, err
+	}
+
+	return &next, nil
+}
+
+// ClaimQueued holds the delivery until the given time, only if nobody changed
+// it since it was read, so one worker at a time sends it.
+func (r *webhookBreakerRepository) ClaimQueued(item *model.QueuedWebhook, until time.Time) (bool, error) {
+	query := r.db.Model(&model.QueuedWebhook{}).Where("id = ?", item.ID)
+	if item.NextAttemptAt == nil {
+		query = query.Where("next_attempt_at IS NULL")
+	} else {
+		query = query.Where("next_attempt_at = ?", *item.NextAttemptAt)
+	}
+
+	res := query.Update("next_attempt_at", until)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+func (r *webhookBreakerRepository) RetryQueued(id uint, attempts int, lastError string, at time.Time) error {
+	return r.db.Model(&model.QueuedWebhook{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"attempts": attempts, "last_error": lastError, "next_attempt_at": at}).Error
+}
+
+func (r *webhookBreakerRepository) DeadLetterQueued(id uint, attempts int, lastError string) error {
+	return r.db.Model(&model.QueuedWebhook{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"attempts": attempts, "last_error": lastError, "dead_lettered_at": time.Now()}).Error
+}
+
+// FindStuckOrdered returns the tenant's ordered deliveries that have failed.
+// Only the head of a resource is ever tried, so these are all heads.
+func (r *webhookBreakerRepository) FindStuckOrdered(tenantID uint) ([]model.QueuedWebhook, error) {
+	var items []model.QueuedWebhook
+	err := r.db.Where("breaker_id = 0 AND tenant_id = ? AND attempts > 0", tenantID).Order("id").Find(&items).Error
+	return items, err
+}
+
+// SkipOrdered drops a failed ordered delivery of the tenant so the next one of
+// its resource can go. It reports false when there is no such delivery.
+func (r *webhookBreakerRepository) SkipOrdered(tenantID uint, id uint) (bool, error) {
+	res := r.db.Where("id = ? AND tenant_id = ? AND breaker_id = 0 AND attempts > 0", id, tenantID).
+		Delete(&model.QueuedWebhook{})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+// ResumeOrdered gives a failed ordered delivery of the tenant a fresh set of
+// attempts, starting now. It reports false when there is no such delivery.
+func (r *webhookBreakerRepository) ResumeOrdered(tenantID uint, id uint) (bool, error) {
+	res := r.db.Model(&model.QueuedWebhook{}).
+		Where("id = ? AND tenant_id = ? AND breaker_id = 0 AND attempts > 0", id, tenantID).
+		Updates(map[string]interface{}{"attempts": 0, "next_attempt_at": nil, "dead_lettered_at": nil})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	model "payment-broker/internal/model/db"
+	"payment-broker/internal/model/dto"
+	"strconv"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type WebhookDeliveryRepository interface {
+	Create(delivery *model.WebhookDelivery) error
+	FindByTenant(tenantID uint, filter dto.WebhookDeliveryFilter) ([]model.WebhookDelivery, error)
+}
+
+type webhookDeliveryRepository struct {
+	logger *zap.Logger
+	db     *gorm.DB
+}
+
+func NewWebhookDeliveryRepository(logger *zap.Logger, db *gorm.DB) WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+func (r *webhookDeliveryRepository) Create(delivery *model.WebhookDelivery) error {
+	return r.db.Create(delivery).Error
+}
+
+// FindByTenant returns the newest attempts first, starting below the filter's
+// BeforeID when it isn't zero.
+func (r *webhookDeliveryRepository) FindByTenant(tenantID uint, filter dto.WebhookDeliveryFilter) ([]model.WebhookDelivery, error) {
+	query := r.db.Where("tenant_id = ?", tenantID)
+	if filter.EventType != "" {
+		query = query.Where("event_type = ?", filter.EventType)
+	}
+
+	switch filter.Status {
+	case "":
+	case "succeeded":
+		query = query.Where("succeeded = ?", true)
+	case "failed":
+		query = query.Where("succeeded = ?", false)
+	default:
+		code, _ := strconv.Atoi(filter.Status)
+		query = query.Where("response_status = ?", code)
+	}
+
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at <= ?", filter.To)
+	}
+	if filter.BeforeID > 0 {
+		query = query.Where("id < ?", filter.BeforeID)
+	}
+
+	var deliveries []model.WebhookDelivery
+	err := query.Order("id DESC").Limit(filter.Limit).Find(&deliveries).Error
+	return deliveries, err
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	model "payment-broker/internal/model/db"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type WebhookEndpointRepository interface {
+	Create(endpoint *model.WebhookEndpoint) error
+	Save(endpoint *model.WebhookEndpoint) error
+	FindForTenant(tenantID uint, id uint) (*model.WebhookEndpoint, error)
+	FindByTenant(tenantID uint) ([]model.WebhookEndpoint, error)
+	Delete(tenantID uint, id uint) error
+}
+
+type webhookEndpointRepository struct {
+	logger *zap.Logger
+	db     *gorm.DB
+}
+
+func NewWebhookEndpointRepository(logger *zap.Logger, db *gorm.DB) WebhookEndpointRepository {
+	return &webhookEndpointRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+func (r *webhookEndpointRepository) Create(endpoint *model.WebhookEndpoint) error {
+	return r.db.Create(endpoint).Error
+}
+
+func (r *webhookEndpointRepository) Save(endpoint *model.WebhookEndpoint) error {
+	return r.db.Save(endpoint).Error
+}
+
+func (r *webhookEndpointRepository) FindForTenant(tenantID uint, id uint) (*model.WebhookEndpoint, error) {
+	var endpoint model.WebhookEndpoint
+	err := r.db.Where("tenant_id = ? AND id = ?", tenantID, id).First(&endpoint).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("webhook endpoint not found")
+		}
+		r.logger.Error("webhookEndpointRepository.FindForTenant", zap.Uint("tenant_id", tenantID), zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	return &endpoint, nil
+}
+
+func (r *webhookEndpointRepository) FindByTenant(tenantID uint) ([]model.WebhookEndpoint, error) {
+	var endpoints []model.WebhookEndpoint
+	err := r.db.Where("tenant_id = ?", tenantID).Order("id").Find(&endpoints).Error
+	return endpoints, err
+}
+
+func (r *webhookEndpointRepository) Delete(tenantID uint, id uint) error {
+	res := r.db.Where("tenant_id = ? AND id = ?", tenantID, id).Delete(&model.WebhookEndpoint{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("webhook endpoint not found")
+	}
+	return nil
+}
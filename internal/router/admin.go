@@ -0,0 +1,51 @@
+package router
+
+import (
+	"payment-broker/internal/controller"
+	"payment-broker/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func NewAdminRouter(app fiber.Router, splitRuleController controller.SplitRuleController, payoutLimitController controller.PayoutLimitController,
+	payoutApprovalController controller.PayoutApprovalController, beneficiaryController controller.BeneficiaryController,
+	feeController controller.FeeController, reconciliationController controller.ReconciliationController,
+	ledgerController controller.LedgerController, tenantController controller.TenantController,
+	webhookDeliveryController controller.WebhookDeliveryController) {
+	adminAPI := app.Group("/admin")
+	adminAPI.Use(middleware.AdminMiddleware())
+
+	adminAPI.Post("/split-rules", splitRuleController.CreateSplitRule)
+
+	adminAPI.Get("/payout-approvals", payoutApprovalController.ListApprovals)
+	adminAPI.Get("/payout-approvals/:id", payoutApprovalController.GetApproval)
+	adminAPI.Post("/payout-approvals/:id/approve", payoutApprovalController.ApprovePayout)
+	adminAPI.Post("/payout-approvals/:id/reject", payoutApprovalController.RejectPayout)
+
+	adminAPI.Get("/beneficiaries", beneficiaryController.ListBeneficiaries)
+	adminAPI.Post("/beneficiaries/:id/verify", beneficiaryController.VerifyBeneficiary)
+	adminAPI.Post("/beneficiaries/:id/reject", beneficiaryController.RejectBeneficiary)
+
+	adminAPI.Post("/reconciliations", reconciliationController.StartReconciliation)
+	adminAPI.Get("/reconciliations", reconciliationController.ListReconciliations)
+	adminAPI.Get("/reconciliations/:id", reconciliationController.GetReconciliation)
+
+	adminAPITenant := adminAPI.Group("/tenants/:id")
+	adminAPITenant.Get("/currencies", tenantController.GetTenantCurrencies)
+	adminAPITenant.Put("/currencies", tenantController.SetTenantCurrencies)
+	adminAPITenant.Get("/split-rules", splitRuleController.GetTenantSplitRules)
+	adminAPITenant.Put("/split-rules", splitRuleController.AttachSplitRule)
+	adminAPITenant.Delete("/split-rules", splitRuleController.DetachSplitRule)
+	adminAPITenant.Get("/payout-limits", payoutLimitController.GetPayoutLimit)
+	adminAPITenant.Put("/payout-limits", payoutLimitController.SetPayoutLimit)
+	adminAPITenant.Get("/fee-schedule", feeController.GetFeeSchedule)
+	adminAPITenant.Put("/fee-schedule", feeController.SetFeeSchedule)
+	adminAPITenant.Get("/fee-report", feeController.GetFeeReport)
+	adminAPITenant.Get("/ledger", ledgerController.GetLedgerBalances)
+	adminAPITenant.Get("/ledger/entries", ledgerController.ListLedgerEntries)
+	adminAPITenant.Get("/webhook-deliveries", webhookDeliveryController.ListTenantWebhookDeliveries)
+	adminAPITenant.Post("/webhook-test", webhookDeliveryController.SendTestWebhook)
+	adminAPITenant.Get("/webhook-breakers", webhookDeliveryController.GetWebhookBreakers)
+	adminAPITenant.Post("/webhook-queue/:queueID/skip", webhookDeliveryController.SkipQueuedWebhook)
+	adminAPITenant.Post("/webhook-queue/:queueID/retry", webhookDeliveryController.ResumeQueuedWebhook)
+}
@@ -0,0 +1,20 @@
+package router
+
+import (
+	"payment-broker/internal/controller"
+	"payment-broker/internal/middleware"
+	"payment-broker/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func NewEventRouter(app fiber.Router, tenantService service.TenantService, usageService service.UsageService,
+	eventController controller.EventController) {
+	eventAPI := app.Group("/events")
+
+	eventAPI.Use(middleware.XenditMiddleware(tenantService))
+	eventAPI.Use(middleware.UsageMiddleware(usageService))
+	eventAPI.Get("/", eventController.ListEvents)
+	eventAPI.Post("/ack", eventController.AcknowledgeEvents)
+	eventAPI.Get("/stream", eventController.StreamEvents)
+}
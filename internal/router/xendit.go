@@ -8,16 +8,41 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func NewXenditRouter(app fiber.Router, tenantService service.TenantService, xenditController controller.XenditController, webhookController controller.WebhookController) {
+func NewXenditRouter(app fiber.Router, tenantService service.TenantService, usageService service.UsageService, xenditController controller.XenditController, webhookController controller.WebhookController,
+	subscriptionController controller.SubscriptionController, payoutController controller.PayoutController,
+	payoutApprovalController controller.PayoutApprovalController, beneficiaryController controller.BeneficiaryController,
+	webhookEndpointController controller.WebhookEndpointController, webhookDeliveryController controller.WebhookDeliveryController) {
 	xenditAPI := app.Group("/xendit")
 
 	xenditAPIAction := xenditAPI.Group("/action")
 
 	xenditAPIAction.Use(middleware.XenditMiddleware(tenantService))
+	xenditAPIAction.Use(middleware.UsageMiddleware(usageService))
 	xenditAPIAction.Post("/invoices", xenditController.CreatePayment)
 	xenditAPIAction.Post("/recurring/plans", xenditController.CreateSubscription)
+	xenditAPIAction.Get("/recurring/plans", subscriptionController.ListPlans)
+	xenditAPIAction.Get("/recurring/plans/:id", subscriptionController.GetPlan)
+	xenditAPIAction.Patch("/recurring/plans/:id", subscriptionController.UpdatePlan)
+	xenditAPIAction.Post("/recurring/plans/:id/deactivate", subscriptionController.DeactivatePlan)
+	xenditAPIAction.Get("/recurring/plans/:id/cycles", subscriptionController.ListCycles)
 	xenditAPIAction.Post("/payouts", xenditController.CreatePayout)
+	xenditAPIAction.Get("/payouts", payoutController.GetPayoutsByReference)
+	xenditAPIAction.Get("/payouts/:id", payoutController.GetPayout)
+	xenditAPIAction.Get("/payouts/:id/status", payoutController.GetPayoutStatus)
+	xenditAPIAction.Post("/payouts/:id/cancel", payoutController.CancelPayout)
+	xenditAPIAction.Get("/payouts_channels", payoutController.GetPayoutChannels)
+	xenditAPIAction.Get("/payout-approvals/:id", payoutApprovalController.GetTenantApproval)
+	xenditAPIAction.Post("/beneficiaries", beneficiaryController.RegisterBeneficiary)
+	xenditAPIAction.Get("/beneficiaries", beneficiaryController.GetTenantBeneficiaries)
+	xenditAPIAction.Delete("/beneficiaries/:id", beneficiaryController.DeleteBeneficiary)
+	xenditAPIAction.Post("/webhook-endpoints", webhookEndpointController.RegisterWebhookEndpoint)
+	xenditAPIAction.Get("/webhook-endpoints", webhookEndpointController.GetWebhookEndpoints)
+	xenditAPIAction.Put("/webhook-endpoints/:id", webhookEndpointController.UpdateWebhookEndpoint)
+	xenditAPIAction.Delete("/webhook-endpoints/:id", webhookEndpointController.DeleteWebhookEndpoint)
+	xenditAPIAction.Get("/webhook-deliveries", webhookDeliveryController.ListWebhookDeliveries)
 	xenditAPIAction.Post("/customers", xenditController.CreateCustomer)
+	xenditAPIAction.Get("/balance", xenditController.GetBalance)
+	xenditAPIAction.Get("/transactions", xenditController.GetTransactions)
 
 	xenditAPIWebhook := xenditAPI.Group("/webhook")
 	xenditAPIWebhook.Post("/", middleware.XenditWebhookMiddleware(), webhookController.WebhookHandler)
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"fmt"
+	"payment-broker/internal/helper"
+	model "payment-broker/internal/model/db"
+	"payment-broker/internal/model/dto"
+	"payment-broker/internal/repository"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type BeneficiaryService interface {
+	Register(tenantID string, request *dto.BeneficiaryRequest) (*model.Beneficiary, error)
+	GetTenantBeneficiaries(tenantID string) ([]model.Beneficiary, error)
+	DeleteTenantBeneficiary(tenantID string, id uint) error
+	GetBeneficiaries(status string) ([]model.Beneficiary, error)
+	Verify(id uint, reviewer string) (*model.Beneficiary, error)
+	Reject(id uint, reviewer string) (*model.Beneficiary, error)
+	CheckDestination(tenantID uint, request *dto.PayoutRequest) (string, error)
+}
+
+type beneficiaryService struct {
+	logger                *zap.Logger
+	payoutLimitService    PayoutLimitService
+	beneficiaryRepository repository.BeneficiaryRepository
+}
+
+func NewBeneficiaryService(logger *zap.Logger, payoutLimitService PayoutLimitService,
+	beneficiaryRepository repository.BeneficiaryRepository) BeneficiaryService {
+	return &beneficiaryService{
+		logger:                logger,
+		payoutLimitService:    payoutLimitService,
+		beneficiaryRepository: beneficiaryRepository,
+	}
+}
+
+// Register adds a destination pending operator verification. Registering the
+// same destination again returns the existing entry unchanged.
+func (s *beneficiaryService) Register(tenantID string, request *dto.BeneficiaryRequest) (*model.Beneficiary, error) {
+	tid, err := helper.ParseTenantID(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.beneficiaryRepository.FindDestination(tid, request.ChannelCode, request.AccountNumber)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return existing, nil
+	}
+
+	beneficiary := &model.Beneficiary{
+		TenantID:          tid,
+		ChannelCode:       request.ChannelCode,
+		AccountNumber:     request.AccountNumber,
+		AccountHolderName: request.AccountHolderName,
+		Status:            model.BeneficiaryPending,
+	}
+	if err := s.beneficiaryRepository.Create(beneficiary); err != nil {
+		s.logger.Error("beneficiaryRepository.Create", zap.Uint("tenant_id", tid), zap.Error(err))
+		return nil, fmt.Errorf("failed to register beneficiary: %w", err)
+	}
+
+	return beneficiary, nil
+}
+
+func (s *beneficiaryService) GetTenantBeneficiaries(tenantID string) ([]model.Beneficiary, error) {
+	tid, err := helper.ParseTenantID(tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return s.beneficiaryRepository.FindByTenant(tid)
+}
+
+func (s *beneficiaryService) DeleteTenantBeneficiary(tenantID string, id uint) error {
+	tid, err := helper.ParseTenantID(tenantID)
+	if err != nil {
+		return err
+	}
+	return s.beneficiaryRepository.Delete(tid, id)
+}
+
+func (s *beneficiaryService) GetBeneficiaries(status string) ([]model.Beneficiary, error) {
+	return s.beneficiaryRepository.FindByStatus(status)
+}
+
+func (s *beneficiaryService) Verify(id uint, reviewer string) (*model.Beneficiary, error) {
+	return s.review(id, reviewer, model.BeneficiaryVerified)
+}
+
+func (s *beneficiaryService) Reject(id uint, reviewer string) (*model.Beneficiary, error) {
+	return s.review(id, reviewer, model.BeneficiaryRejected)
+}
+
+func (s *beneficiaryService) review(id uint, reviewer string, status string) (*model.Beneficiary, error) {
+	if reviewer == "" {
+		return nil, fmt.Errorf("reviews require a personal admin key from ADMIN_API_KEYS")
+	}
+
+	err := s.beneficiaryRepository.UpdateStatus(id, model.BeneficiaryPending, map[string]interface{}{
+		"status":      status,
+		"reviewed_by": reviewer,
+		"reviewed_at": time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.beneficiaryRepository.Find(id)
+}
+
+// CheckDestination returns the tenant's beneficiary policy when the payout
+// destination isn't a verified beneficiary, or is one under a different holder
+// name, and an empty string when it may be sent. Tenants without a policy get
+// APPROVAL.
+func (s *beneficiaryService) CheckDestination(tenantID uint, request *dto.PayoutRequest) (string, error) {
+	limit, err := s.payoutLimitService.GetLimit(tenantID)
+	if err != nil {
+		return "", err
+	}
+
+	policy := limit.BeneficiaryPolicy
+	if policy == "" {
+		policy = model.BeneficiaryPolicyApproval
+	}
+	if request.ChannelProperties == nil {
+		return policy, nil
+	}
+
+	beneficiary, err := s.beneficiaryRepository.FindDestination(tenantID, request.ChannelCode, request.ChannelProperties.AccountNumber)
+	if err != nil {
+		return "", err
+	}
+	if beneficiary != nil && beneficiary.Status == model.BeneficiaryVerified &&
+		sameHolderName(beneficiary.AccountHolderName, request.ChannelProperties.AccountHolderName) {
+		return "", nil
+	}
+
+	return policy, nil
+}
+
+// sameHolderName compares account holder names ignoring case and spacing.
+func sameHolderName(a, b string) bool {
+	return strings.EqualFold(strings.Join(strings.Fields(a), " "), strings.Join(strings.Fields(b), " "))
+}
@@ -1,8 +1,9 @@
 package service
 
 import (
+	"context"
 	"fmt"
-	"regexp"
+	"payment-broker/internal/helper"
 
 	"github.com/AlecAivazis/survey/v2"
 )
@@ -12,14 +13,54 @@ type CLIService interface {
 	AddTenant()
 	ViewTenants()
 	DeleteTenant()
+	SetTenantCurrencies()
+	CreateSplitRule()
+	ViewSplitRules()
+	DetachSplitRule()
+	SetPayoutLimits()
+	ReviewPayoutApprovals()
+	VerifyBeneficiaries()
+	ViewFeeReport()
+	ExportUsage()
+	RunReconciliation()
+	ImportSettlementReport()
+	ViewWebhookDeliveries()
+	SendTestWebhook()
 }
 
 type cliService struct {
-	tenantService TenantService
+	tenantService          TenantService
+	splitRuleService       SplitRuleService
+	payoutLimitService     PayoutLimitService
+	payoutApprovalService  PayoutApprovalService
+	beneficiaryService     BeneficiaryService
+	feeService             FeeService
+	usageService           UsageService
+	reconciliationService  ReconciliationService
+	settlementService      SettlementService
+	webhookDeliveryService WebhookDeliveryService
+	webhookPingService     WebhookPingService
+	egress                 *helper.EgressPolicy
 }
 
-func NewCLIService(tenantService TenantService) CLIService {
-	return &cliService{tenantService: tenantService}
+func NewCLIService(tenantService TenantService, splitRuleService SplitRuleService, payoutLimitService PayoutLimitService,
+	payoutApprovalService PayoutApprovalService, beneficiaryService BeneficiaryService, feeService FeeService,
+	usageService UsageService, reconciliationService ReconciliationService, settlementService SettlementService,
+	webhookDeliveryService WebhookDeliveryService, webhookPingService WebhookPingService, egress *helper.EgressPolicy) CLIService {
+	return &cliService{
+		tenantService:          tenantService,
+		splitRuleService:       splitRuleService,
+		payoutLimitService:     payoutLimitService,
+		payoutApprovalService:  payoutApprovalService,
+		beneficiaryService:     beneficiaryService,
+		feeService:             feeService,
+		usageService:           usageService,
+		reconciliationService:  reconciliationService,
+		settlementService:      settlementService,
+		webhookDeliveryService: webhookDeliveryService,
+		webhookPingService:     webhookPingService,
+		egress:                 egress,
+	}
 }
 
 func (h *cliService) MainMenu() {
@@ -31,6 +72,19 @@ func (h *cliService) MainMenu() {
 				"Add Tenant",
 				"View Tenants",
 				"Delete Tenant",
+				"Set Tenant Currencies",
+				"Create Split Rule",
+				"View Split Rules",
+				"Detach Split Rule",
+				"Set Payout Limits",
+				"Review Payout Approvals",
+				"Verify Beneficiaries",
+				"View Fee Report",
+				"Export Usage",
+				"Run Reconciliation",
+				"Import Settlement Report",
+				"View Webhook Deliveries",
+				"Send Test Webhook",
 				"Exit",
 			},
 		}
@@ -43,6 +97,32 @@ func (h *cliService) MainMenu() {
 			h.ViewTenants()
 		case "Delete Tenant":
 			h.DeleteTenant()
+		case "Set Tenant Currencies":
+			h.SetTenantCurrencies()
+		case "Create Split Rule":
+			h.CreateSplitRule()
+		case "View Split Rules":
+			h.ViewSplitRules()
+		case "Detach Split Rule":
+			h.DetachSplitRule()
+		case "Set Payout Limits":
+			h.SetPayoutLimits()
+		case "Review Payout Approvals":
+			h.ReviewPayoutApprovals()
+		case "Verify Beneficiaries":
+			h.VerifyBeneficiaries()
+		case "View Fee Report":
+			h.ViewFeeReport()
+		case "Export Usage":
+			h.ExportUsage()
+		case "Run Reconciliation":
+			h.RunReconciliation()
+		case "Import Settlement Report":
+			h.ImportSettlementReport()
+		case "View Webhook Deliveries":
+			h.ViewWebhookDeliveries()
+		case "Send Test Webhook":
+			h.SendTestWebhook()
 		case "Exit":
 			fmt.Println("👋 Goodbye!")
 			return
@@ -58,9 +138,21 @@ func (h *cliService) AddTenant() {
 			Validate: survey.Required,
 		},
 		{
-			Name:     "accountID",
-			Prompt:   &survey.Input{Message: "Enter Xendit Account ID:"},
-			Validate: survey.Required,
+			Name:   "email",
+			Prompt: &survey.Input{Message: "Enter sub-account email:"},
+			Validate: func(val interface{}) error {
+				if str, ok := val.(string); !ok || !helper.IsEmail(str) {
+					return fmt.Errorf("must be a valid email address")
+				}
+				return nil
+			},
+		},
+		{
+			Name: "accountType",
+			Prompt: &survey.Select{
+				Message: "Choose Xendit account type:",
+				Options: []string{"OWNED", "MANAGED"},
+			},
 		},
 		{
 			Name:   "webhookURL",
@@ -68,12 +160,8 @@ func (h *cliService) AddTenant() {
 			Validate: func(val interface{}) error {
 				if str, ok := val.(string); !ok || str == "" {
 					return fmt.Errorf("webhook URL is required")
-				} else {
-					// Validate URL format
-					matched, _ := regexp.MatchString(`^https?://.*`, str)
-					if !matched {
-						return fmt.Errorf("must be a valid URL starting with http:// or https://")
-					}
+				} else if err := h.egress.CheckURL(context.Background(), str); err != nil {
+					return err
 				}
 				return nil
 			},
@@ -81,9 +169,10 @@ func (h *cliService) AddTenant() {
 	}
 
 	answers := struct {
-		Name       string
-		AccountID  string `survey:"accountID"`
-		WebhookURL string `survey:"webhookURL"`
+		Name        string
+		Email       string
+		AccountType string `survey:"accountType"`
+		WebhookURL  string `survey:"webhookURL"`
 	}{}
 
 	err := survey.Ask(questions, &answers)
@@ -92,7 +181,7 @@ func (h *cliService) AddTenant() {
 		return
 	}
 
-	tenant, err := h.tenantService.CreateTenant(answers.Name, answers.AccountID, answers.WebhookURL)
+	tenant, err := h.tenantService.OnboardTenant(context.Background(), answers.Name, answers.Email, answers.AccountType, answers.WebhookURL)
 	if err != nil {
 		fmt.Println("❌ Failed to create tenant:", err)
 		return
@@ -103,6 +192,7 @@ func (h *cliService) AddTenant() {
 	fmt.Printf("ID:          %d\n", tenant.ID)
 	fmt.Printf("Name:        %s\n", tenant.Name)
 	fmt.Printf("Account ID:  %s\n", tenant.AccountID)
+	fmt.Printf("Account:     %s (%s)\n", tenant.AccountType, tenant.AccountStatus)
 	fmt.Printf("Webhook URL: %s\n", tenant.WebhookURL)
 	fmt.Printf("API Key:     %s\n", tenant.APIKey)
 	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n")
@@ -116,7 +206,7 @@ func (h *cliService) ViewTenants() {
 	}
 
 	if len(tenants) == 0 {
-		fmt.Println("\n📭 No tenants found\n")
+		fmt.Print("\n📭 No tenants found\n\n")
 		return
 	}
 
@@ -124,7 +214,11 @@ func (h *cliService) ViewTenants() {
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	for _, tenant := range tenants {
 		fmt.Printf("ID: %d | Name: %-20s | Account ID: %-24s\n", tenant.ID, tenant.Name, tenant.AccountID)
+		fmt.Printf("       Account: %s (%s)\n", tenant.AccountType, tenant.AccountStatus)
 		fmt.Printf("       Webhook: %s\n", tenant.WebhookURL)
+		if tenant.AllowedCurrencies != "" || tenant.DefaultCurrency != "" {
+			fmt.Printf("       Currencies: %s (default %s)\n", tenant.AllowedCurrencies, tenant.DefaultCurrency)
+		}
 		fmt.Printf("       API Key: %s\n", tenant.APIKey)
 		fmt.Println("──────────────────────────────────────────────────────────────────")
 	}
@@ -132,15 +226,43 @@ func (h *cliService) ViewTenants() {
 }
 
 func (h *cliService) DeleteTenant() {
+	selectedID, ok := h.selectTenant("Select tenant to delete:")
+	if !ok {
+		return
+	}
+
+	confirm := false
+	confirmPrompt := &survey.Confirm{
+		Message: fmt.Sprintf("Are you sure you want to delete tenant ID %d?", selectedID),
+	}
+	survey.AskOne(confirmPrompt, &confirm)
+
+	if !confirm {
+		fmt.Printf("❌ Cancelled\n")
+		return
+	}
+
+	err := h.tenantService.DeleteTenant(selectedID)
+	if err != nil {
+		fmt.Println("❌ Failed to delete tenant:", err)
+		return
+	}
+
+	fmt.Printf("✅ Tenant ID %d deleted successfully!\n\n", selectedID)
+}
+
+// selectTenant prompts for a tenant and reports false when there is none to
+// pick or the operator cancels.
+func (h *cliService) selectTenant(message string) (uint, bool) {
 	tenants, err := h.tenantService.GetAllTenants()
 	if err != nil {
 		fmt.Println("❌ Error:", err)
-		return
+		return 0, false
 	}
 
 	if len(tenants) == 0 {
-		fmt.Println("\n📭 No tenants found\n")
-		return
+		fmt.Print("\n📭 No tenants found\n\n")
+		return 0, false
 	}
 
 	options := make([]string, len(tenants))
@@ -151,35 +273,34 @@ func (h *cliService) DeleteTenant() {
 
 	var choice string
 	prompt := &survey.Select{
-		Message: "Select tenant to delete:",
+		Message: message,
 		Options: options,
 	}
 	survey.AskOne(prompt, &choice)
 
-	if choice == "Cancel" {
+	if choice == "Cancel" || choice == "" {
 		fmt.Printf("❌ Cancelled\n")
-		return
+		return 0, false
 	}
 
 	var selectedID uint
 	fmt.Sscanf(choice, "ID: %d", &selectedID)
+	return selectedID, true
+}
 
-	confirm := false
-	confirmPrompt := &survey.Confirm{
-		Message: fmt.Sprintf("Are you sure you want to delete tenant ID %d?", selectedID),
-	}
-	survey.AskOne(confirmPrompt, &confirm)
-
-	if !confirm {
-		fmt.Printf("❌ Cancelled\n")
-		return
+// askAdminIdentity asks for the reviewer's personal admin key and returns the
+// admin it belongs to in ADMIN_API_KEYS.
+func askAdminIdentity() (string, bool) {
+	var key string
+	if err := survey.AskOne(&survey.Password{Message: "Your admin key:"}, &key, survey.WithValidator(survey.Required)); err != nil {
+		fmt.Println("❌ Error:", err)
+		return "", false
 	}
 
-	err = h.tenantService.DeleteTenant(selectedID)
-	if err != nil {
-		fmt.Println("❌ Failed to delete tenant:", err)
-		return
+	name, ok := helper.AdminIdentity(key)
+	if !ok {
+		fmt.Print("❌ Unknown admin key, reviews require a personal key from ADMIN_API_KEYS\n\n")
+		return "", false
 	}
-
-	fmt.Printf("✅ Tenant ID %d deleted successfully!\n\n", selectedID)
+	return name, true
 }
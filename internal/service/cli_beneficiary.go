@@ -0,0 +1,66 @@
+package service
+
+import (
+	"fmt"
+	model "payment-broker/internal/model/db"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+func (h *cliService) VerifyBeneficiaries() {
+	beneficiaries, err := h.beneficiaryService.GetBeneficiaries(model.BeneficiaryPending)
+	if err != nil {
+		fmt.Println("❌ Error:", err)
+		return
+	}
+
+	if len(beneficiaries) == 0 {
+		fmt.Print("\n📭 No beneficiaries waiting for verification\n\n")
+		return
+	}
+
+	options := make([]string, len(beneficiaries))
+	for i, beneficiary := range beneficiaries {
+		options[i] = fmt.Sprintf("ID: %d - tenant %d, %s %s (%s)", beneficiary.ID, beneficiary.TenantID,
+			beneficiary.ChannelCode, beneficiary.AccountNumber, beneficiary.AccountHolderName)
+	}
+	options = append(options, "Cancel")
+
+	var choice string
+	survey.AskOne(&survey.Select{Message: "Select beneficiary to review:", Options: options}, &choice)
+
+	if choice == "Cancel" || choice == "" {
+		fmt.Printf("❌ Cancelled\n")
+		return
+	}
+
+	var selectedID uint
+	fmt.Sscanf(choice, "ID: %d", &selectedID)
+
+	reviewer, ok := askAdminIdentity()
+	if !ok {
+		return
+	}
+
+	var decision string
+	survey.AskOne(&survey.Select{Message: "Decision:", Options: []string{"Verify", "Reject", "Cancel"}}, &decision)
+
+	switch decision {
+	case "Verify":
+		if _, err := h.beneficiaryService.Verify(selectedID, reviewer); err != nil {
+			fmt.Println("❌ Failed to verify beneficiary:", err)
+			return
+		}
+		fmt.Printf("✅ Beneficiary ID %d verified\n\n", selectedID)
+
+	case "Reject":
+		if _, err := h.beneficiaryService.Reject(selectedID, reviewer); err != nil {
+			fmt.Println("❌ Failed to reject beneficiary:", err)
+			return
+		}
+		fmt.Printf("✅ Beneficiary ID %d rejected\n\n", selectedID)
+
+	default:
+		fmt.Printf("❌ Cancelled\n")
+	}
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"fmt"
+	"payment-broker/internal/helper"
+	"payment-broker/internal/model/dto"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+func (h *cliService) SetTenantCurrencies() {
+	selectedID, ok := h.selectTenant("Select tenant:")
+	if !ok {
+		return
+	}
+
+	current, err := h.tenantService.GetCurrencies(selectedID)
+	if err != nil {
+		fmt.Println("❌ Error:", err)
+		return
+	}
+
+	var allowed []string
+	err = survey.AskOne(&survey.MultiSelect{
+		Message: "Allowed currencies:",
+		Options: helper.SupportedCurrencies,
+		Default: current.AllowedCurrencies,
+	}, &allowed, survey.WithValidator(survey.MinItems(1)))
+	if err != nil {
+		fmt.Println("❌ Error:", err)
+		return
+	}
+
+	defaultCurrency := current.DefaultCurrency
+	if !helper.IsAllowedCurrency(allowed, defaultCurrency) {
+		defaultCurrency = allowed[0]
+	}
+
+	err = survey.AskOne(&survey.Select{
+		Message: "Default currency:",
+		Options: allowed,
+		Default: defaultCurrency,
+	}, &defaultCurrency)
+	if err != nil {
+		fmt.Println("❌ Error:", err)
+		return
+	}
+
+	// Allowing every currency is stored as an empty list, so currencies Xendit
+	// adds later are allowed too.
+	if len(allowed) == len(helper.SupportedCurrencies) {
+		allowed = nil
+	}
+
+	currencies, err := h.tenantService.SetCurrencies(selectedID, dto.TenantCurrenciesRequest{
+		AllowedCurrencies: allowed,
+		DefaultCurrency:   defaultCurrency,
+	})
+	if err != nil {
+		fmt.Println("❌ Failed to save currencies:", err)
+		return
+	}
+
+	fmt.Printf("\n✅ Currencies saved for tenant ID %d\n", selectedID)
+	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+	fmt.Printf("Allowed:  %s\n", strings.Join(currencies.AllowedCurrencies, ", "))
+	fmt.Printf("Default:  %s\n", currencies.DefaultCurrency)
+	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n")
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+func (h *cliService) ViewFeeReport() {
+	selectedID, ok := h.selectTenant("Select tenant:")
+	if !ok {
+		return
+	}
+
+	var month string
+	survey.AskOne(&survey.Input{Message: "Month (YYYY-MM):", Default: time.Now().UTC().Format("2006-01")}, &month)
+
+	report, err := h.feeService.GetMonthlyReport(selectedID, month)
+	if err != nil {
+		fmt.Println("❌ Error:", err)
+		return
+	}
+
+	if len(report.Fees) == 0 {
+		fmt.Printf("\n📭 No paid invoices for tenant ID %d in %s\n\n", selectedID, report.Month)
+		return
+	}
+
+	fmt.Printf("\n💰 Platform fees for tenant ID %d in %s\n", selectedID, report.Month)
+	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+	for _, fee := range report.Fees {
+		fmt.Printf("%s  %-24s %-14s %12s %s  fee %s\n", fee.PaidAt.Format("2006-01-02"), fee.ExternalID,
+			fee.PaymentChannel, formatAmount(fee.Amount), fee.Currency, formatAmount(fee.Fee))
+	}
+	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+	for _, total := range report.Totals {
+		fmt.Printf("%s: %d invoices, %s paid, %s in fees\n", total.Currency, total.Count,
+			formatAmount(total.Amount), formatAmount(total.Fee))
+	}
+	fmt.Println()
+}
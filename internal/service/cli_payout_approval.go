@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	model "payment-broker/internal/model/db"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+func (h *cliService) ReviewPayoutApprovals() {
+	approvals, err := h.payoutApprovalService.GetApprovals(model.PayoutApprovalPending)
+	if err != nil {
+		fmt.Println("❌ Error:", err)
+		return
+	}
+
+	if len(approvals) == 0 {
+		fmt.Print("\n📭 No payouts waiting for approval\n\n")
+		return
+	}
+
+	options := make([]string, len(approvals))
+	for i, approval := range approvals {
+		options[i] = fmt.Sprintf("ID: %d - tenant %d, %.2f %s to %s by %s", approval.ID, approval.TenantID,
+			approval.Amount, approval.Currency, approval.ChannelCode, approval.RequestedBy)
+	}
+	options = append(options, "Cancel")
+
+	var choice string
+	survey.AskOne(&survey.Select{Message: "Select payout to review:", Options: options}, &choice)
+
+	if choice == "Cancel" || choice == "" {
+		fmt.Printf("❌ Cancelled\n")
+		return
+	}
+
+	var selectedID uint
+	fmt.Sscanf(choice, "ID: %d", &selectedID)
+
+	history, err := h.payoutApprovalService.GetHistory(selectedID)
+	if err == nil {
+		fmt.Println("\n🧾 History:")
+		for _, event := range history {
+			fmt.Printf("  %s  %-9s by %s %s\n", event.CreatedAt.Format("2006-01-02 15:04:05"), event.Action, event.Actor, event.Note)
+		}
+		fmt.Println()
+	}
+
+	reviewer, ok := askAdminIdentity()
+	if !ok {
+		return
+	}
+
+	var decision string
+	survey.AskOne(&survey.Select{Message: "Decision:", Options: []string{"Approve", "Reject", "Cancel"}}, &decision)
+
+	switch decision {
+	case "Approve":
+		approval, _, err := h.payoutApprovalService.Approve(context.Background(), selectedID, reviewer)
+		if err != nil {
+			fmt.Println("❌ Failed to approve payout:", err)
+			return
+		}
+		if approval.Status != model.PayoutApprovalApproved {
+			fmt.Printf("❌ Payout approved but not sent: %s\n\n", approval.Reason)
+			return
+		}
+		fmt.Printf("✅ Payout approved and sent to Xendit as %s\n\n", approval.PayoutID)
+
+	case "Reject":
+		var reason string
+		survey.AskOne(&survey.Input{Message: "Reason:"}, &reason)
+
+		if _, err := h.payoutApprovalService.Reject(selectedID, reviewer, reason); err != nil {
+			fmt.Println("❌ Failed to reject payout:", err)
+			return
+		}
+		fmt.Printf("✅ Payout approval ID %d rejected\n\n", selectedID)
+
+	default:
+		fmt.Printf("❌ Cancelled\n")
+	}
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"fmt"
+	model "payment-broker/internal/model/db"
+	"payment-broker/internal/model/dto"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+func (h *cliService) SetPayoutLimits() {
+	selectedID, ok := h.selectTenant("Select tenant:")
+	if !ok {
+		return
+	}
+
+	current, err := h.payoutLimitService.GetLimit(selectedID)
+	if err != nil {
+		fmt.Println("❌ Error:", err)
+		return
+	}
+
+	validateNumber := func(val interface{}) error {
+		if f, err := strconv.ParseFloat(val.(string), 64); err != nil || f < 0 {
+			return fmt.Errorf("must be 0 or a positive number")
+		}
+		return nil
+	}
+
+	currencies := make([]string, 0, len(current.Currencies))
+	for _, currency := range current.Currencies {
+		currencies = append(currencies, currency.Currency)
+	}
+
+	questions := []*survey.Question{
+		{
+			Name:     "hourly",
+			Prompt:   &survey.Input{Message: "Max payouts per hour (0 = no limit):", Default: strconv.Itoa(current.HourlyCount)},
+			Validate: validateNumber,
+		},
+		{
+			Name:   "channels",
+			Prompt: &survey.Input{Message: "Allowed channel codes, comma separated (empty = all):", Default: current.AllowedChannels},
+		},
+		{
+			Name:   "currencies",
+			Prompt: &survey.Input{Message: "Currencies with amount limits, comma separated (others are refused, empty = no amount limits):", Default: strings.Join(currencies, ",")},
+		},
+		{
+			Name: "beneficiaryPolicy",
+			Prompt: &survey.Select{
+				Message: "Payouts to unverified beneficiaries:",
+				Options: []string{model.BeneficiaryPolicyApproval, model.BeneficiaryPolicyReject},
+				Default: beneficiaryPolicyOption(current.BeneficiaryPolicy),
+			},
+		},
+	}
+
+	answers := struct {
+		Hourly     string
+		Channels   string
+		Currencies string
+
+		BeneficiaryPolicy string `survey:"beneficiaryPolicy"`
+	}{}
+
+	if err := survey.Ask(questions, &answers); err != nil {
+		fmt.Println("❌ Error:", err)
+		return
+	}
+
+	hourly, _ := strconv.ParseFloat(answers.Hourly, 64)
+
+	var channels []string
+	if answers.Channels != "" {
+		channels = strings.Split(answers.Channels, ",")
+	}
+
+	var currencyLimits []dto.PayoutCurrencyLimitRequest
+	for _, currency := range strings.Split(answers.Currencies, ",") {
+		currency = strings.ToUpper(strings.TrimSpace(currency))
+		if currency == "" {
+			continue
+		}
+
+		existing := findCurrencyLimit(current, currency)
+		if existing == nil {
+			existing = &model.PayoutCurrencyLimit{}
+		}
+
+		currencyQuestions := []*survey.Question{
+			{
+				Name:     "maxSingle",
+				Prompt:   &survey.Input{Message: currency + " max single payout amount (0 = no limit):", Default: formatAmount(existing.MaxSingleAmount)},
+				Validate: validateNumber,
+			},
+			{
+				Name:     "daily",
+				Prompt:   &survey.Input{Message: currency + " daily payout amount (0 = no limit):", Default: formatAmount(existing.DailyAmount)},
+				Validate: validateNumber,
+			},
+			{
+				Name:     "monthly",
+				Prompt:   &survey.Input{Message: currency + " monthly payout amount (0 = no limit):", Default: formatAmount(existing.MonthlyAmount)},
+				Validate: validateNumber,
+			},
+			{
+				Name:     "approval",
+				Prompt:   &survey.Input{Message: currency + " hold payouts for approval from amount (0 = never):", Default: formatAmount(existing.ApprovalThreshold)},
+				Validate: validateNumber,
+			},
+		}
+
+		currencyAnswers := struct {
+			MaxSingle string `survey:"maxSingle"`
+			Daily     string
+			Monthly   string
+			Approval  string
+		}{}
+
+		if err := survey.Ask(currencyQuestions, &currencyAnswers); err != nil {
+			fmt.Println("❌ Error:", err)
+			return
+		}
+
+		maxSingle, _ := strconv.ParseFloat(currencyAnswers.MaxSingle, 64)
+		daily, _ := strconv.ParseFloat(currencyAnswers.Daily, 64)
+		monthly, _ := strconv.ParseFloat(currencyAnswers.Monthly, 64)
+		approval, _ := strconv.ParseFloat(currencyAnswers.Approval, 64)
+
+		currencyLimits = append(currencyLimits, dto.PayoutCurrencyLimitRequest{
+			Currency:          currency,
+			MaxSingleAmount:   maxSingle,
+			DailyAmount:       daily,
+			MonthlyAmount:     monthly,
+			ApprovalThreshold: approval,
+		})
+	}
+
+	limit, err := h.payoutLimitService.SetLimit(selectedID, dto.PayoutLimitRequest{
+		HourlyCount:       int(hourly),
+		AllowedChannels:   channels,
+		Currencies:        currencyLimits,
+		BeneficiaryPolicy: answers.BeneficiaryPolicy,
+	})
+	if err != nil {
+		fmt.Println("❌ Failed to save payout limits:", err)
+		return
+	}
+
+	fmt.Printf("\n✅ Payout limits saved for tenant ID %d\n", selectedID)
+	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+	fmt.Printf("Per hour:    %d\n", limit.HourlyCount)
+	fmt.Printf("Channels:    %s\n", limit.AllowedChannels)
+	fmt.Printf("Unverified:  %s\n", beneficiaryPolicyOption(limit.BeneficiaryPolicy))
+	for _, currency := range limit.Currencies {
+		fmt.Printf("%s:         single %s, daily %s, monthly %s, approval at %s\n", currency.Currency,
+			formatAmount(currency.MaxSingleAmount), formatAmount(currency.DailyAmount),
+			formatAmount(currency.MonthlyAmount), formatAmount(currency.ApprovalThreshold))
+	}
+	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n")
+}
+
+func beneficiaryPolicyOption(policy string) string {
+	if policy == "" {
+		return model.BeneficiaryPolicyApproval
+	}
+	return policy
+}
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', -1, 64)
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+func (h *cliService) RunReconciliation() {
+	answers := struct {
+		Hours    string
+		SelfHeal bool `survey:"selfHeal"`
+	}{}
+
+	err := survey.Ask([]*survey.Question{
+		{
+			Name:   "hours",
+			Prompt: &survey.Input{Message: "Reconcile the last how many hours:", Default: "24"},
+			Validate: func(val interface{}) error {
+				if n, err := strconv.Atoi(val.(string)); err != nil || n < 1 {
+					return fmt.Errorf("must be a positive whole number")
+				}
+				return nil
+			},
+		},
+		{
+			Name:   "selfHeal",
+			Prompt: &survey.Confirm{Message: "Update local statuses to match Xendit?", Default: false},
+		},
+	}, &answers)
+	if err != nil {
+		fmt.Println("❌ Error:", err)
+		return
+	}
+
+	hours, _ := strconv.Atoi(answers.Hours)
+	to := time.Now()
+
+	run, err := h.reconciliationService.Begin(to.Add(-time.Duration(hours)*time.Hour), to, answers.SelfHeal)
+	if err != nil {
+		fmt.Println("❌ Failed to start reconciliation:", err)
+		return
+	}
+
+	fmt.Println("⏳ Reconciling with Xendit...")
+	h.reconciliationService.Execute(context.Background(), run)
+
+	_, mismatches, err := h.reconciliationService.GetRun(run.ID)
+	if err != nil {
+		fmt.Println("❌ Error:", err)
+		return
+	}
+
+	fmt.Printf("\n🔎 Reconciliation run ID %d: %s\n", run.ID, run.Status)
+	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+	fmt.Printf("Checked:     %d\n", run.Checked)
+	fmt.Printf("Mismatches:  %d\n", run.Mismatches)
+	fmt.Printf("Healed:      %d\n", run.Healed)
+	for _, m := range mismatches {
+		healed := ""
+		if m.Healed {
+			healed = " (healed)"
+		}
+		fmt.Printf("tenant %d %-7s %-28s %-15s local %s/%s remote %s/%s%s\n", m.TenantID, m.Resource, m.ResourceID, m.Type,
+			m.LocalStatus, formatAmount(m.LocalAmount), m.RemoteStatus, formatAmount(m.RemoteAmount), healed)
+	}
+	if run.Error != "" {
+		fmt.Printf("Errors:\n%s\n", run.Error)
+	}
+	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n")
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+func (h *cliService) ImportSettlementReport() {
+	var path string
+	err := survey.AskOne(&survey.Input{Message: "Path to Xendit report CSV:"}, &path, survey.WithValidator(survey.Required))
+	if err != nil {
+		fmt.Println("❌ Error:", err)
+		return
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		fmt.Println("❌ Failed to open report:", err)
+		return
+	}
+	defer file.Close()
+
+	statement, err := h.settlementService.Import(file)
+	if err != nil {
+		fmt.Println("❌ Failed to import report:", err)
+		return
+	}
+
+	fmt.Printf("\n🧾 Settlement statement: %d of %d lines matched\n", statement.Matched, statement.Lines)
+	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+	for _, tenant := range statement.Tenants {
+		fmt.Printf("Tenant %d - %s\n", tenant.TenantID, tenant.TenantName)
+		for _, total := range tenant.Totals {
+			fmt.Printf("  %s  %d transactions  gross %s  payouts %s  refunds %s  fees %s  split %s  net %s\n", total.Currency, total.Count,
+				formatAmount(total.Gross), formatAmount(total.Payouts), formatAmount(total.Refunds), formatAmount(total.Fees),
+				formatAmount(total.Split), formatAmount(total.Net))
+		}
+	}
+	if len(statement.Unmatched) > 0 {
+		fmt.Println("Unmatched lines:")
+		for _, line := range statement.Unmatched {
+			fmt.Printf("  line %d %s: %s\n", line.Line, line.Reference, line.Reason)
+		}
+	}
+	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	output := fmt.Sprintf("settlement-statement-%s.csv", name)
+
+	out, err := os.Create(output)
+	if err != nil {
+		fmt.Println("❌ Failed to write statement:", err)
+		return
+	}
+	defer out.Close()
+
+	if err := h.settlementService.WriteStatementCSV(statement, out); err != nil {
+		fmt.Println("❌ Failed to write statement:", err)
+		return
+	}
+	fmt.Printf("✅ Statement saved to %s\n\n", output)
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"payment-broker/internal/model/dto"
+	"strconv"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+const defaultSplitRuleScope = "All endpoints (tenant default)"
+
+func (h *cliService) CreateSplitRule() {
+	selectedID, ok := h.selectTenant("Select tenant for the split rule:")
+	if !ok {
+		return
+	}
+
+	questions := []*survey.Question{
+		{
+			Name:     "name",
+			Prompt:   &survey.Input{Message: "Enter split rule name:"},
+			Validate: survey.Required,
+		},
+		{
+			Name: "kind",
+			Prompt: &survey.Select{
+				Message: "Choose platform fee type:",
+				Options: []string{"percent", "flat"},
+			},
+		},
+		{
+			Name:   "amount",
+			Prompt: &survey.Input{Message: "Enter platform fee amount:"},
+			Validate: func(val interface{}) error {
+				if f, err := strconv.ParseFloat(val.(string), 64); err != nil || f <= 0 {
+					return fmt.Errorf("must be a positive number")
+				}
+				return nil
+			},
+		},
+		{
+			Name:     "currency",
+			Prompt:   &survey.Input{Message: "Enter currency:", Default: "IDR"},
+			Validate: survey.Required,
+		},
+		{
+			Name:     "destination",
+			Prompt:   &survey.Input{Message: "Enter destination account ID:", Default: os.Getenv("XENDIT_PLATFORM_ACCOUNT_ID")},
+			Validate: survey.Required,
+		},
+		{
+			Name: "endpoint",
+			Prompt: &survey.Select{
+				Message: "Apply to endpoint:",
+				Options: append([]string{defaultSplitRuleScope}, SplitRuleEndpoints...),
+			},
+		},
+	}
+
+	answers := struct {
+		Name        string
+		Kind        string
+		Amount      string
+		Currency    string
+		Destination string
+		Endpoint    string
+	}{}
+
+	if err := survey.Ask(questions, &answers); err != nil {
+		fmt.Println("❌ Error:", err)
+		return
+	}
+
+	amount, _ := strconv.ParseFloat(answers.Amount, 64)
+	route := dto.XenditSplitRuleRoute{
+		Currency:             answers.Currency,
+		DestinationAccountID: answers.Destination,
+		ReferenceID:          fmt.Sprintf("platform-fee-%d", selectedID),
+	}
+	if answers.Kind == "percent" {
+		route.PercentAmount = &amount
+	} else {
+		route.FlatAmount = &amount
+	}
+
+	rule, err := h.splitRuleService.CreateSplitRule(context.Background(), dto.XenditCreateSplitRule{
+		Name:   answers.Name,
+		Routes: []dto.XenditSplitRuleRoute{route},
+	})
+	if err != nil {
+		fmt.Println("❌ Failed to create split rule:", err)
+		return
+	}
+
+	endpoint := answers.Endpoint
+	if endpoint == defaultSplitRuleScope {
+		endpoint = ""
+	}
+
+	if _, err := h.splitRuleService.AttachSplitRule(selectedID, dto.AttachSplitRule{
+		SplitRuleID: rule.ID,
+		Endpoint:    endpoint,
+	}); err != nil {
+		fmt.Printf("❌ Split rule %s created but not attached: %v\n", rule.ID, err)
+		return
+	}
+
+	fmt.Printf("✅ Split rule %s attached to tenant ID %d\n\n", rule.ID, selectedID)
+}
+
+func (h *cliService) ViewSplitRules() {
+	selectedID, ok := h.selectTenant("Select tenant:")
+	if !ok {
+		return
+	}
+
+	rules, err := h.splitRuleService.GetTenantSplitRules(selectedID)
+	if err != nil {
+		fmt.Println("❌ Error:", err)
+		return
+	}
+
+	if len(rules) == 0 {
+		fmt.Print("\n📭 No split rules attached, the platform default applies\n\n")
+		return
+	}
+
+	fmt.Println("\n🧾 Split Rules:")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	for _, rule := range rules {
+		endpoint := rule.Endpoint
+		if endpoint == "" {
+			endpoint = defaultSplitRuleScope
+		}
+		fmt.Printf("Endpoint: %-32s | Split Rule: %s\n", endpoint, rule.XenditSplitRuleID)
+	}
+	fmt.Println()
+}
+
+func (h *cliService) DetachSplitRule() {
+	selectedID, ok := h.selectTenant("Select tenant:")
+	if !ok {
+		return
+	}
+
+	var endpoint string
+	prompt := &survey.Select{
+		Message: "Detach split rule from:",
+		Options: append([]string{defaultSplitRuleScope}, SplitRuleEndpoints...),
+	}
+	survey.AskOne(prompt, &endpoint)
+
+	if endpoint == defaultSplitRuleScope {
+		endpoint = ""
+	}
+
+	if err := h.splitRuleService.DetachSplitRule(selectedID, endpoint); err != nil {
+		fmt.Println("❌ Failed to detach split rule:", err)
+		return
+	}
+
+	fmt.Printf("✅ Split rule detached from tenant ID %d\n\n", selectedID)
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+func (h *cliService) ExportUsage() {
+	answers := struct {
+		Month  string
+		Format string
+	}{}
+
+	err := survey.Ask([]*survey.Question{
+		{
+			Name:     "month",
+			Prompt:   &survey.Input{Message: "Month (YYYY-MM):", Default: time.Now().UTC().Format("2006-01")},
+			Validate: survey.Required,
+		},
+		{
+			Name:   "format",
+			Prompt: &survey.Select{Message: "Format:", Options: []string{"CSV", "JSON", "Both"}},
+		},
+	}, &answers)
+	if err != nil {
+		fmt.Println("❌ Error:", err)
+		return
+	}
+
+	formats := []string{strings.ToLower(answers.Format)}
+	if answers.Format == "Both" {
+		formats = []string{"csv", "json"}
+	}
+
+	for _, format := range formats {
+		path := fmt.Sprintf("usage-%s.%s", answers.Month, format)
+		if err := h.exportUsageFile(answers.Month, format, path); err != nil {
+			fmt.Println("❌ Failed to export usage:", err)
+			return
+		}
+		fmt.Printf("✅ Usage for %s exported to %s\n", answers.Month, path)
+	}
+	fmt.Println()
+}
+
+func (h *cliService) exportUsageFile(month, format, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := h.usageService.Export(month, format, file); err != nil {
+		file.Close()
+		os.Remove(path)
+		return err
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"fmt"
+	"payment-broker/internal/model/dto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+func (h *cliService) ViewWebhookDeliveries() {
+	selectedID, ok := h.selectTenant("Select tenant:")
+	if !ok {
+		return
+	}
+
+	answers := struct {
+		EventType string `survey:"eventType"`
+		Status    string
+		Hours     string
+	}{}
+
+	err := survey.Ask([]*survey.Question{
+		{
+			Name:   "eventType",
+			Prompt: &survey.Input{Message: "Event type (leave empty for all):"},
+		},
+		{
+			Name:   "status",
+			Prompt: &survey.Select{Message: "Status:", Options: []string{"All", "Succeeded", "Failed"}},
+		},
+		{
+			Name:   "hours",
+			Prompt: &survey.Input{Message: "Show the last how many hours:", Default: "24"},
+			Validate: func(val interface{}) error {
+				if n, err := strconv.Atoi(val.(string)); err != nil || n < 1 {
+					return fmt.Errorf("must be a positive whole number")
+				}
+				return nil
+			},
+		},
+	}, &answers)
+	if err != nil {
+		fmt.Println("❌ Error:", err)
+		return
+	}
+
+	hours, _ := strconv.Atoi(answers.Hours)
+	filter := dto.WebhookDeliveryFilter{
+		EventType: strings.TrimSpace(answers.EventType),
+		From:      time.Now().Add(-time.Duration(hours) * time.Hour),
+		Limit:     100,
+	}
+	if answers.Status != "All" {
+		filter.Status = strings.ToLower(answers.Status)
+	}
+
+	deliveries, err := h.webhookDeliveryService.GetDeliveries(selectedID, filter)
+	if err != nil {
+		fmt.Println("❌ Error:", err)
+		return
+	}
+
+	if len(deliveries) == 0 {
+		fmt.Printf("\n📭 No webhook deliveries for tenant ID %d in the last %d hours\n\n", selectedID, hours)
+		return
+	}
+
+	fmt.Printf("\n📨 Webhook deliveries for tenant ID %d, newest first\n", selectedID)
+	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+	for _, d := range deliveries {
+		result := "✅"
+		if !d.Succeeded {
+			result = "❌"
+		}
+		fmt.Printf("%s %s  %-24s %-36s %3d %5dms  %s\n", result, d.CreatedAt.Format("2006-01-02 15:04:05"),
+			d.EventType, d.EventID, d.ResponseStatus, d.LatencyMs, d.URL)
+		if d.Error != "" {
+			fmt.Printf("   %s\n", d.Error)
+		}
+	}
+	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+	if len(deliveries) == filter.Limit {
+		fmt.Printf("Showing the newest %d, narrow the filters to see older ones\n", filter.Limit)
+	}
+	fmt.Println()
+}
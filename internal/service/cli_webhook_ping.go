@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"payment-broker/internal/model/dto"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+func (h *cliService) SendTestWebhook() {
+	selectedID, ok := h.selectTenant("Select tenant:")
+	if !ok {
+		return
+	}
+
+	var eventType string
+	survey.AskOne(&survey.Select{Message: "Sample event:", Options: dto.WebhookTestEventTypes}, &eventType)
+
+	fmt.Printf("⏳ Sending %s to tenant ID %d...\n", eventType, selectedID)
+	deliveries, err := h.webhookPingService.SendTestEvent(context.Background(), selectedID, eventType)
+	if err != nil {
+		fmt.Println("❌ Error:", err)
+		return
+	}
+
+	fmt.Printf("\n📨 Test %s for tenant ID %d\n", eventType, selectedID)
+	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+	for _, d := range deliveries {
+		if d.Succeeded {
+			fmt.Printf("✅ %s responded %d in %dms\n", d.URL, d.ResponseStatus, d.LatencyMs)
+		} else {
+			fmt.Printf("❌ %s failed after %dms: %s\n", d.URL, d.LatencyMs, d.Error)
+		}
+		if d.ResponseBody != "" {
+			fmt.Printf("   %s\n", d.ResponseBody)
+		}
+	}
+	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n")
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"payment-broker/internal/model/dto"
+	"strings"
+	"time"
+)
+
+// eventTimeFields are the resource fields that say when a callback happened,
+// most specific first. A legacy invoice's created is when the invoice was
+// made, so it only counts when nothing later is there.
+var eventTimeFields = []string{"paid_at", "updated", "created"}
+
+// NewEvent normalizes a Xendit callback for the tenant. The ID is derived from
+// the payload so a retried callback keeps it, and the time falls back to now
+// when the payload doesn't carry one.
+func NewEvent(tenantID uint, rawBody []byte) dto.Event {
+	var body map[string]interface{}
+	_ = json.Unmarshal(rawBody, &body)
+
+	sum := sha256.Sum256(rawBody)
+	event := dto.Event{
+		SpecVersion:     dto.EventSpecVersion,
+		ID:              "evt_" + hex.EncodeToString(sum[:16]),
+		Type:            EventType(body),
+		Source:          "xendit",
+		Time:            time.Now().UTC(),
+		TenantID:        tenantID,
+		Version:         dto.EventVersion,
+		DataContentType: "application/json",
+		Data:            json.RawMessage(rawBody),
+	}
+
+	resource := body
+	data, isEvent := body["data"].(map[string]interface{})
+	if isEvent {
+		resource = data
+	}
+	if id, ok := resource["id"].(string); ok {
+		event.Subject = id
+	}
+
+	// Event callbacks, shaped {event, created, data}, say when the event
+	// happened in the top-level created.
+	occurredAt, ok := findTime(resource, eventTimeFields...)
+	if isEvent {
+		if created, found := findTime(body, "created"); found {
+			occurredAt, ok = created, true
+		}
+	}
+	if ok {
+		event.Time = occurredAt.UTC()
+	}
+
+	return event
+}
+
+// findTime returns the first of fields in source holding an RFC 3339 time.
+func findTime(source map[string]interface{}, fields ...string) (time.Time, bool) {
+	for _, field := range fields {
+		if value, ok := source[field].(string); ok {
+			if t, err := time.Parse(time.RFC3339, value); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// EventType names a callback for endpoint subscriptions and the envelope.
+// Legacy invoice callbacks carry no event, so they are named after their
+// status, e.g. invoice.paid.
+func EventType(body map[string]interface{}) string {
+	if event, ok := body["event"].(string); ok && event != "" {
+		return event
+	}
+
+	if _, isInvoice := body["external_id"]; isInvoice {
+		if status, ok := body["status"].(string); ok && status != "" {
+			return "invoice." + strings.ToLower(status)
+		}
+		return "invoice"
+	}
+
+	return "callback"
+}
+
+var errPayloadNoID = errors.New("payload has no id")
+
+// payloadError explains why a callback's data can't be recorded, given the
+// error decoding it and the resource ID it decoded to, or returns nil. Such a
+// payload won't read any better when Xendit retries it, so callers log it and
+// skip the broker's record instead of failing the callback.
+func payloadError(decodeErr error, id string) error {
+	if decodeErr != nil {
+		return fmt.Errorf("invalid payload: %w", decodeErr)
+	}
+	if id == "" {
+		return errPayloadNoID
+	}
+	return nil
+}
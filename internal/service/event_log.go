@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"payment-broker/internal/helper"
+	model "payment-broker/internal/model/db"
+	"payment-broker/internal/model/dto"
+	"payment-broker/internal/repository"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultEventRetention   = 7 * 24 * time.Hour
+	defaultEventSettleDelay = 3 * time.Second
+)
+
+type EventLogService interface {
+	Record(event dto.Event) error
+	GetTenantEvents(tenantID string, filter dto.EventFilter) ([]dto.LoggedEvent, error)
+	Acknowledge(tenantID string, cursors []uint) (int64, error)
+	Latest(tenantID string) (uint, error)
+	Subscribe(tenantID string) (<-chan struct{}, func())
+	StartRetention(ctx context.Context)
+}
+
+type eventLogService struct {
+	logger          *zap.Logger
+	mu              sync.Mutex
+	subscribers     map[string]map[chan struct{}]struct{}
+	settleDelay     time.Duration
+	eventRepository repository.EventRepository
+}
+
+// NewEventLogService hands out events only once they are EVENT_SETTLE_DELAY
+// (3s by default) old, so an event still being committed is never skipped by
+// a cursor that moved past it. The delay must exceed how long recording an
+// event can take plus any clock difference between instances.
+func NewEventLogService(logger *zap.Logger, eventRepository repository.EventRepository) EventLogService {
+	settleDelay, err := time.ParseDuration(os.Getenv("EVENT_SETTLE_DELAY"))
+	if err != nil || settleDelay < 0 {
+		settleDelay = defaultEventSettleDelay
+	}
+
+	return &eventLogService{
+		logger:          logger,
+		subscribers:     make(map[string]map[chan struct{}]struct{}),
+		settleDelay:     settleDelay,
+		eventRepository: eventRepository,
+	}
+}
+
+// Record appends the event to its tenant's log and wakes the tenant's streams
+// on this instance. Streams on other instances pick it up when they poll.
+func (s *eventLogService) Record(event dto.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	err = s.eventRepository.Create(&model.TenantEvent{
+		TenantID:    event.TenantID,
+		EventID:     event.ID,
+		Type:        event.Type,
+		Subject:     event.Subject,
+		Payload:     string(payload),
+		OccurredAt:  event.Time,
+		Unpublished: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	s.notify(strconv.FormatUint(uint64(event.TenantID), 10))
+	return nil
+}
+
+// GetTenantEvents returns the tenant's events selected by filter, oldest first.
+func (s *eventLogService) GetTenantEvents(tenantID string, filter dto.EventFilter) ([]dto.LoggedEvent, error) {
+	tid, err := helper.ParseTenantID(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.eventRepository.FindByTenant(tid, filter, s.settled())
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]dto.LoggedEvent, 0, len(rows))
+	for _, row := range rows {
+		logged := dto.LoggedEvent{Cursor: row.ID, AcknowledgedAt: row.AcknowledgedAt}
+		if err := json.Unmarshal([]byte(row.Payload), &logged.Event); err != nil {
+			s.logger.Error("Failed to decode logged event", zap.Uint("id", row.ID), zap.Error(err))
+			continue
+		}
+		events = append(events, logged)
+	}
+	return events, nil
+}
+
+// Acknowledge marks the tenant's events at cursors consumed and returns how
+// many were not already. Cursors of other tenants' events are ignored.
+func (s *eventLogService) Acknowledge(tenantID string, cursors []uint) (int64, error) {
+	tid, err := helper.ParseTenantID(tenantID)
+	if err != nil {
+		return 0, err
+	}
+	return s.eventRepository.Acknowledge(tid, cursors, time.Now())
+}
+
+// Latest returns the cursor of the tenant's newest logged event, or 0.
+func (s *eventLogService) Latest(tenantID string) (uint, error) {
+	tid, err := helper.ParseTenantID(tenantID)
+	if err != nil {
+		return 0, err
+	}
+	return s.eventRepository.FindLatestID(tid, s.settled())
+}
+
+// settled is the creation time up to which logged events can be handed out.
+func (s *eventLogService) settled() time.Time {
+	return time.Now().Add(-s.settleDelay)
+}
+
+// Subscribe returns a channel that receives a signal whenever an event is
+// logged for the tenant, and a func that releases it.
+func (s *eventLogService) Subscribe(tenantID string) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	s.mu.Lock()
+	if s.subscribers[tenantID] == nil {
+		s.subscribers[tenantID] = make(map[chan struct{}]struct{})
+	}
+	s.subscribers[tenantID][ch] = struct{}{}
+	s.mu.Unlock()
+
+	return ch, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.subscribers[tenantID], ch)
+		if len(s.subscribers[tenantID]) == 0 {
+			delete(s.subscribers, tenantID)
+		}
+	}
+}
+
+func (s *eventLogService) notify(tenantID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for ch := range s.subscribers[tenantID] {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// StartRetention drops logged events older than EVENT_RETENTION (7 days by
+// default) every hour until ctx is done.
+func (s *eventLogService) StartRetention(ctx context.Context) {
+	retention, err := time.ParseDuration(os.Getenv("EVENT_RETENTION"))
+	if err != nil || retention <= 0 {
+		retention = defaultEventRetention
+	}
+
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				deleted, err := s.eventRepository.DeleteBefore(time.Now().Add(-retention))
+				if err != nil {
+					s.logger.Error("eventRepository.DeleteBefore", zap.Error(err))
+					continue
+				}
+				if deleted > 0 {
+					s.logger.Info("Expired logged events", zap.Int64("deleted", deleted))
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	model "payment-broker/internal/model/db"
+	"payment-broker/internal/model/dto"
+	"payment-broker/internal/repository"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// memoryEventRepository filters by ID and creation time like the database.
+type memoryEventRepository struct {
+	repository.EventRepository
+	events []model.TenantEvent
+}
+
+func (r *memoryEventRepository) FindByTenant(tenantID uint, filter dto.EventFilter, settled time.Time) ([]model.TenantEvent, error) {
+	var events []model.TenantEvent
+	for _, event := range r.events {
+		if event.TenantID == tenantID && event.ID > filter.After && !event.CreatedAt.After(settled) {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (r *memoryEventRepository) FindLatestID(tenantID uint, settled time.Time) (uint, error) {
+	var latest uint
+	for _, event := range r.events {
+		if event.TenantID == tenantID && !event.CreatedAt.After(settled) && event.ID > latest {
+			latest = event.ID
+		}
+	}
+	return latest, nil
+}
+
+func TestEventLogHoldsBackUnsettledEvents(t *testing.T) {
+	now := time.Now()
+	repo := &memoryEventRepository{events: []model.TenantEvent{
+		{ID: 1, TenantID: 1, Payload: `{"id":"evt_1"}`, CreatedAt: now.Add(-time.Minute)},
+		{ID: 2, TenantID: 1, Payload: `{"id":"evt_2"}`, CreatedAt: now.Add(-10 * time.Second)},
+		{ID: 4, TenantID: 1, Payload: `{"id":"evt_4"}`, CreatedAt: now},
+		{ID: 5, TenantID: 2, Payload: `{"id":"evt_5"}`, CreatedAt: now.Add(-time.Minute)},
+		// ID 3 is still committing after ID 4 became visible. Neither is
+		// settled, so a reader's cursor must not move past 3 yet.
+		{ID: 3, TenantID: 1, Payload: `{"id":"evt_3"}`, CreatedAt: now.Add(-time.Second)},
+	}}
+	s := &eventLogService{logger: zap.NewNop(), settleDelay: 3 * time.Second, eventRepository: repo}
+
+	events, err := s.GetTenantEvents("1", dto.EventFilter{Limit: 50})
+	if err != nil {
+		t.Fatalf("GetTenantEvents() error = %v", err)
+	}
+
+	var cursors []uint
+	for _, event := range events {
+		cursors = append(cursors, event.Cursor)
+	}
+	if len(cursors) != 2 || cursors[0] != 1 || cursors[1] != 2 {
+		t.Errorf("GetTenantEvents() cursors = %v, want [1 2]", cursors)
+	}
+
+	latest, err := s.Latest("1")
+	if err != nil {
+		t.Fatalf("Latest() error = %v", err)
+	}
+	if latest != 2 {
+		t.Errorf("Latest() = %d, want 2", latest)
+	}
+}
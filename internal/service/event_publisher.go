@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"os"
+	"payment-broker/internal/lib"
+	model "payment-broker/internal/model/db"
+	"payment-broker/internal/repository"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// eventPublishTimeout keeps a slow message bus from holding the relay's
+	// lock on a batch for long.
+	eventPublishTimeout = 3 * time.Second
+
+	defaultEventRelayInterval = time.Second
+	eventRelayBatch           = 100
+)
+
+type EventPublisherService interface {
+	StartRelay(ctx context.Context)
+	Relay(ctx context.Context) (int, error)
+}
+
+type eventPublisherService struct {
+	logger          *zap.Logger
+	publisher       lib.Publisher
+	eventRepository repository.EventRepository
+}
+
+func NewEventPublisherService(logger *zap.Logger, publisher lib.Publisher, eventRepository repository.EventRepository) EventPublisherService {
+	return &eventPublisherService{
+		logger:          logger,
+		publisher:       publisher,
+		eventRepository: eventRepository,
+	}
+}
+
+// StartRelay publishes logged events to the message bus every
+// EVENT_RELAY_INTERVAL (1s by default) until ctx is done. Events are published
+// at least once: a relay that stops after publishing but before marking the
+// batch publishes it again, so consumers dedupe on the event_id attribute.
+func (s *eventPublisherService) StartRelay(ctx context.Context) {
+	interval, err := time.ParseDuration(os.Getenv("EVENT_RELAY_INTERVAL"))
+	if err != nil || interval <= 0 {
+		interval = defaultEventRelayInterval
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				for ctx.Err() == nil {
+					published, err := s.Relay(ctx)
+					if err != nil {
+						s.logger.Error("eventPublisherService.Relay", zap.Error(err))
+					}
+					if err != nil || published < eventRelayBatch {
+						break
+					}
+				}
+			}
+		}
+	}()
+}
+
+// Relay publishes the oldest batch of unpublished events and returns how many
+// were published. It stops at the first event the bus refuses, so the rest
+// keep their order and are tried again on the next run.
+func (s *eventPublisherService) Relay(ctx context.Context) (int, error) {
+	var publishErr error
+	published, err := s.eventRepository.PublishPending(eventRelayBatch, func(events []model.TenantEvent) []uint {
+		ids := make([]uint, 0, len(events))
+		for _, event := range events {
+			if publishErr = s.publish(ctx, event); publishErr != nil {
+				break
+			}
+			ids = append(ids, event.ID)
+		}
+		return ids
+	})
+	if err != nil {
+		return published, err
+	}
+	return published, publishErr
+}
+
+// publish sends the logged event envelope to the message bus, keyed by tenant
+// so a partitioned bus keeps each tenant's events in order. The tenant ID,
+// event type and event ID are set as attributes.
+func (s *eventPublisherService) publish(ctx context.Context, event model.TenantEvent) error {
+	tenantID := strconv.FormatUint(uint64(event.TenantID), 10)
+
+	ctx, cancel := context.WithTimeout(ctx, eventPublishTimeout)
+	defer cancel()
+
+	return s.publisher.Publish(ctx, lib.Message{
+		Key:  tenantID,
+		Body: []byte(event.Payload),
+		Attributes: map[string]string{
+			"tenant_id":  tenantID,
+			"event_type": event.Type,
+			"event_id":   event.EventID,
+		},
+	})
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"payment-broker/internal/lib"
+	model "payment-broker/internal/model/db"
+	"payment-broker/internal/repository"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+// outboxEventRepository hands out its unpublished events and marks the IDs
+// the relay reports published.
+type outboxEventRepository struct {
+	repository.EventRepository
+	events []model.TenantEvent
+}
+
+func (r *outboxEventRepository) PublishPending(limit int, publish func(events []model.TenantEvent) []uint) (int, error) {
+	var pending []model.TenantEvent
+	for _, event := range r.events {
+		if event.Unpublished && len(pending) < limit {
+			pending = append(pending, event)
+		}
+	}
+
+	ids := publish(pending)
+	for _, id := range ids {
+		for i := range r.events {
+			if r.events[i].ID == id {
+				r.events[i].Unpublished = false
+			}
+		}
+	}
+	return len(ids), nil
+}
+
+// flakyPublisher refuses messages while down.
+type flakyPublisher struct {
+	down     bool
+	messages []lib.Message
+}
+
+func (p *flakyPublisher) Publish(ctx context.Context, msg lib.Message) error {
+	if p.down && len(p.messages) > 0 {
+		return errors.New("bus unavailable")
+	}
+	p.messages = append(p.messages, msg)
+	return nil
+}
+
+func TestEventRelayPublishesAtLeastOnceInOrder(t *testing.T) {
+	repo := &outboxEventRepository{events: []model.TenantEvent{
+		{ID: 1, TenantID: 7, EventID: "evt_a", Type: "invoice.paid", Payload: `{"id":"evt_a"}`, Unpublished: true},
+		{ID: 2, TenantID: 7, EventID: "evt_b", Type: "payout.succeeded", Payload: `{"id":"evt_b"}`, Unpublished: true},
+		{ID: 3, TenantID: 8, EventID: "evt_c", Type: "refund.succeeded", Payload: `{"id":"evt_c"}`, Unpublished: true},
+	}}
+	publisher := &flakyPublisher{down: true}
+	s := &eventPublisherService{logger: zap.NewNop(), publisher: publisher, eventRepository: repo}
+
+	// The bus accepts the first event and then goes down: the rest stay
+	// unpublished, in order, for the next run.
+	published, err := s.Relay(context.Background())
+	if err == nil {
+		t.Error("Relay() error = nil, want the bus error")
+	}
+	if published != 1 || repo.events[0].Unpublished || !repo.events[1].Unpublished || !repo.events[2].Unpublished {
+		t.Fatalf("Relay() published %d, events %+v", published, repo.events)
+	}
+
+	publisher.down = false
+	published, err = s.Relay(context.Background())
+	if err != nil || published != 2 {
+		t.Fatalf("Relay() = %d, %v, want 2, nil", published, err)
+	}
+
+	var ids []string
+	for _, msg := range publisher.messages {
+		ids = append(ids, msg.Attributes["event_id"])
+	}
+	if len(ids) != 3 || ids[0] != "evt_a" || ids[1] != "evt_b" || ids[2] != "evt_c" {
+		t.Errorf("published event IDs = %v, want [evt_a evt_b evt_c]", ids)
+	}
+
+	last := publisher.messages[2]
+	if last.Key != "8" || last.Attributes["tenant_id"] != "8" || last.Attributes["event_type"] != "refund.succeeded" {
+		t.Errorf("last message = %+v", last)
+	}
+
+	if published, err := s.Relay(context.Background()); err != nil || published != 0 {
+		t.Errorf("Relay() with nothing pending = %d, %v, want 0, nil", published, err)
+	}
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestNewEventTime(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			"event uses top-level created",
+			`{"event":"payout.succeeded","created":"2024-05-02T10:00:00Z","data":{"id":"disb-1","created":"2024-05-01T09:00:00Z","updated":"2024-05-02T09:59:00Z"}}`,
+			"2024-05-02T10:00:00Z",
+		},
+		{
+			"event without created falls back to data",
+			`{"event":"payout.succeeded","data":{"id":"disb-1","created":"2024-05-01T09:00:00Z","updated":"2024-05-02T09:59:00Z"}}`,
+			"2024-05-02T09:59:00Z",
+		},
+		{
+			"legacy invoice prefers paid_at over created",
+			`{"id":"inv-1","external_id":"1:order","status":"PAID","created":"2024-05-01T08:00:00Z","updated":"2024-05-02T11:00:05Z","paid_at":"2024-05-02T11:00:00Z"}`,
+			"2024-05-02T11:00:00Z",
+		},
+		{
+			"legacy invoice prefers updated over created",
+			`{"id":"inv-1","external_id":"1:order","status":"EXPIRED","created":"2024-05-01T08:00:00Z","updated":"2024-05-02T08:00:00Z"}`,
+			"2024-05-02T08:00:00Z",
+		},
+		{
+			"legacy invoice with only created",
+			`{"id":"inv-1","external_id":"1:order","status":"PENDING","created":"2024-05-01T08:00:00Z"}`,
+			"2024-05-01T08:00:00Z",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, _ := time.Parse(time.RFC3339, tt.want)
+			if got := NewEvent(1, []byte(tt.body)).Time; !got.Equal(want) {
+				t.Errorf("NewEvent().Time = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestWebhooksSkipMalformedPayloads(t *testing.T) {
+	subscriptions := &subscriptionService{logger: zap.NewNop()}
+	payouts := &payoutService{logger: zap.NewNop()}
+	ledger := &ledgerService{logger: zap.NewNop()}
+
+	tests := []struct {
+		name   string
+		handle func(tenantID string, event string, data json.RawMessage) error
+		event  string
+		data   string
+	}{
+		{"plan without id", subscriptions.HandleWebhook, "recurring.plan.activated", `{"status":"ACTIVE"}`},
+		{"plan of the wrong shape", subscriptions.HandleWebhook, "recurring.plan.activated", `["not","a","plan"]`},
+		{"cycle without id", subscriptions.HandleWebhook, "recurring.cycle.succeeded", `{"plan_id":"repl_1"}`},
+		{"payout without id", payouts.HandleWebhook, "payout.succeeded", `{"status":"SUCCEEDED"}`},
+		{"payout of the wrong shape", payouts.HandleWebhook, "payout.failed", `{"amount":"ten"}`},
+		{"ledger payout without id", ledger.HandleWebhook, "payout.succeeded", `{"status":"SUCCEEDED"}`},
+		{"ledger refund without id", ledger.HandleWebhook, "refund.succeeded", `{"status":"SUCCEEDED"}`},
+		{"ledger refund of the wrong shape", ledger.HandleWebhook, "refund.failed", `"refund"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.handle("1", tt.event, json.RawMessage(tt.data)); err != nil {
+				t.Errorf("HandleWebhook() error = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestPayloadError(t *testing.T) {
+	if err := payloadError(nil, "id_1"); err != nil {
+		t.Errorf("payloadError() = %v, want nil", err)
+	}
+	if err := payloadError(nil, ""); err != errPayloadNoID {
+		t.Errorf("payloadError() = %v, want %v", err, errPayloadNoID)
+	}
+
+	var data struct{ ID string }
+	decodeErr := json.Unmarshal([]byte(`[]`), &data)
+	if err := payloadError(decodeErr, ""); err == nil || err == errPayloadNoID {
+		t.Errorf("payloadError() = %v, want the decode error", err)
+	}
+}
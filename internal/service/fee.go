@@ -0,0 +1,208 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"payment-broker/internal/helper"
+	model "payment-broker/internal/model/db"
+	"payment-broker/internal/model/dto"
+	"payment-broker/internal/repository"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type FeeService interface {
+	GetSchedule(tenantID uint) ([]model.FeeRule, error)
+	SetSchedule(tenantID uint, body dto.FeeScheduleRequest) ([]model.FeeRule, error)
+	HandleInvoiceCallback(tenantID string, body []byte) error
+	GetMonthlyReport(tenantID uint, month string) (*dto.FeeReport, error)
+}
+
+type feeService struct {
+	logger           *zap.Logger
+	tenantRepository repository.TenantRepository
+	feeRepository    repository.FeeRepository
+}
+
+func NewFeeService(logger *zap.Logger, tenantRepository repository.TenantRepository, feeRepository repository.FeeRepository) FeeService {
+	return &feeService{
+		logger:           logger,
+		tenantRepository: tenantRepository,
+		feeRepository:    feeRepository,
+	}
+}
+
+func (s *feeService) GetSchedule(tenantID uint) ([]model.FeeRule, error) {
+	return s.feeRepository.FindRules(tenantID)
+}
+
+// SetSchedule replaces the tenant's whole fee schedule. Fees already recorded
+// keep the amount computed when the invoice was paid.
+func (s *feeService) SetSchedule(tenantID uint, body dto.FeeScheduleRequest) ([]model.FeeRule, error) {
+	if errs := body.Validate(); len(errs) > 0 {
+		return nil, fmt.Errorf("%s %s", errs[0].Field, errs[0].Message)
+	}
+
+	if _, err := s.tenantRepository.FindByID(tenantID); err != nil {
+		return nil, err
+	}
+
+	rules := make([]model.FeeRule, len(body.Rules))
+	for i, rule := range body.Rules {
+		tiers := make([]model.FeeTier, len(rule.Tiers))
+		for j, tier := range rule.Tiers {
+			tiers[j] = model.FeeTier{UpTo: tier.UpTo, Percentage: tier.Percentage, FlatAmount: tier.FlatAmount}
+		}
+
+		rules[i] = model.FeeRule{
+			TenantID:      tenantID,
+			PaymentMethod: rule.PaymentMethod,
+			Currency:      rule.Currency,
+			Type:          rule.Type,
+			Percentage:    rule.Percentage,
+			FlatAmount:    rule.FlatAmount,
+			Tiers:         tiers,
+		}
+	}
+
+	if err := s.feeRepository.ReplaceRules(tenantID, rules); err != nil {
+		s.logger.Error("feeRepository.ReplaceRules", zap.Uint("tenant_id", tenantID), zap.Error(err))
+		return nil, fmt.Errorf("failed to save fee schedule: %w", err)
+	}
+
+	return s.feeRepository.FindRules(tenantID)
+}
+
+// HandleInvoiceCallback records the platform fee for a paid invoice. Other
+// invoice statuses are ignored.
+func (s *feeService) HandleInvoiceCallback(tenantID string, body []byte) error {
+	var invoice dto.XenditInvoice
+	if err := json.Unmarshal(body, &invoice); err != nil {
+		return fmt.Errorf("invalid invoice callback: %w", err)
+	}
+
+	if invoice.Status != "PAID" && invoice.Status != "SETTLED" {
+		return nil
+	}
+	if invoice.ID == "" {
+		return fmt.Errorf("invoice callback has no id")
+	}
+
+	tid, err := helper.ParseTenantID(tenantID)
+	if err != nil {
+		return err
+	}
+
+	rules, err := s.feeRepository.FindRules(tid)
+	if err != nil {
+		return err
+	}
+
+	amount := invoice.PaidAmount
+	if amount == 0 {
+		amount = invoice.Amount
+	}
+
+	paidAt, err := time.Parse(time.RFC3339, invoice.PaidAt)
+	if err != nil {
+		paidAt = time.Now()
+	}
+
+	var fee float64
+	if rule := matchFeeRule(rules, invoice.PaymentChannel, invoice.PaymentMethod, invoice.Currency); rule != nil {
+		fee = calculateFee(*rule, amount, invoice.Currency)
+	}
+
+	return s.feeRepository.CreateFee(&model.PlatformFee{
+		TenantID:       tid,
+		InvoiceID:      invoice.ID,
+		ExternalID:     helper.StripTenantReference(invoice.ExternalID),
+		PaymentMethod:  invoice.PaymentMethod,
+		PaymentChannel: invoice.PaymentChannel,
+		Currency:       invoice.Currency,
+		Amount:         amount,
+		Fee:            fee,
+		PaidAt:         paidAt.UTC(),
+	})
+}
+
+// GetMonthlyReport lists the fees of invoices paid in the given UTC month
+// (YYYY-MM) with totals per currency.
+func (s *feeService) GetMonthlyReport(tenantID uint, month string) (*dto.FeeReport, error) {
+	from, err := time.Parse("2006-01", month)
+	if err != nil {
+		return nil, fmt.Errorf("month must be in YYYY-MM format")
+	}
+
+	fees, err := s.feeRepository.FindFees(tenantID, from, from.AddDate(0, 1, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	report := &dto.FeeReport{
+		TenantID: tenantID,
+		Month:    month,
+		Totals:   []dto.FeeReportTotal{},
+		Fees:     fees,
+	}
+
+	index := map[string]int{}
+	for _, fee := range fees {
+		i, ok := index[fee.Currency]
+		if !ok {
+			i = len(report.Totals)
+			index[fee.Currency] = i
+			report.Totals = append(report.Totals, dto.FeeReportTotal{Currency: fee.Currency})
+		}
+
+		report.Totals[i].Count++
+		report.Totals[i].Amount = helper.RoundAmount(report.Totals[i].Amount+fee.Amount, fee.Currency)
+		report.Totals[i].Fee = helper.RoundAmount(report.Totals[i].Fee+fee.Fee, fee.Currency)
+	}
+
+	return report, nil
+}
+
+// matchFeeRule prefers a rule for the exact payment channel (e.g. BCA), then the
+// payment method (e.g. BANK_TRANSFER), then the tenant default. At each level a
+// rule for the invoice currency wins over one for any currency.
+func matchFeeRule(rules []model.FeeRule, channel, method, currency string) *model.FeeRule {
+	var keys []string
+	for _, key := range []string{channel, method} {
+		if key != "" {
+			keys = append(keys, key)
+		}
+	}
+
+	for _, key := range append(keys, "") {
+		for _, ruleCurrency := range []string{currency, ""} {
+			for i := range rules {
+				if rules[i].PaymentMethod == key && rules[i].Currency == ruleCurrency {
+					return &rules[i]
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func calculateFee(rule model.FeeRule, amount float64, currency string) float64 {
+	var fee float64
+
+	switch rule.Type {
+	case model.FeeTypeFlat:
+		fee = rule.FlatAmount
+	case model.FeeTypePercentage:
+		fee = amount*rule.Percentage/100 + rule.FlatAmount
+	case model.FeeTypeTiered:
+		for _, tier := range rule.Tiers {
+			if tier.UpTo == 0 || amount <= tier.UpTo {
+				fee = amount*tier.Percentage/100 + tier.FlatAmount
+				break
+			}
+		}
+	}
+
+	return helper.RoundAmount(fee, currency)
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	model "payment-broker/internal/model/db"
+	"testing"
+)
+
+func TestMatchFeeRule(t *testing.T) {
+	rules := []model.FeeRule{
+		{PaymentMethod: "", Currency: "", Type: model.FeeTypeFlat, FlatAmount: 1},
+		{PaymentMethod: "", Currency: "PHP", Type: model.FeeTypeFlat, FlatAmount: 2},
+		{PaymentMethod: "BANK_TRANSFER", Currency: "", Type: model.FeeTypeFlat, FlatAmount: 3},
+		{PaymentMethod: "BANK_TRANSFER", Currency: "IDR", Type: model.FeeTypeFlat, FlatAmount: 4},
+		{PaymentMethod: "BCA", Currency: "", Type: model.FeeTypeFlat, FlatAmount: 5},
+	}
+
+	tests := []struct {
+		name     string
+		channel  string
+		method   string
+		currency string
+		want     float64
+	}{
+		{"channel beats method", "BCA", "BANK_TRANSFER", "IDR", 5},
+		{"method in invoice currency", "MANDIRI", "BANK_TRANSFER", "IDR", 4},
+		{"method in any currency", "MANDIRI", "BANK_TRANSFER", "PHP", 3},
+		{"default in invoice currency", "", "EWALLET", "PHP", 2},
+		{"default in any currency", "OVO", "EWALLET", "IDR", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := matchFeeRule(rules, tt.channel, tt.method, tt.currency)
+			if rule == nil {
+				t.Fatal("no rule matched")
+			}
+			if rule.FlatAmount != tt.want {
+				t.Errorf("matched rule with flat amount %v, want %v", rule.FlatAmount, tt.want)
+			}
+		})
+	}
+
+	if rule := matchFeeRule(rules[2:4], "OVO", "EWALLET", "IDR"); rule != nil {
+		t.Errorf("matched %+v without a default rule, want nil", rule)
+	}
+}
+
+func TestCalculateFee(t *testing.T) {
+	tiered := model.FeeRule{
+		Type: model.FeeTypeTiered,
+		Tiers: []model.FeeTier{
+			{UpTo: 100000, Percentage: 2, FlatAmount: 1000},
+			{UpTo: 1000000, Percentage: 1.5},
+			{UpTo: 0, Percentage: 1},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		rule     model.FeeRule
+		amount   float64
+		currency string
+		want     float64
+	}{
+		{"flat", model.FeeRule{Type: model.FeeTypeFlat, FlatAmount: 2500}, 50000, "IDR", 2500},
+		{"percentage plus flat", model.FeeRule{Type: model.FeeTypePercentage, Percentage: 2.9, FlatAmount: 2000}, 100000, "IDR", 4900},
+		{"percentage rounded to whole IDR", model.FeeRule{Type: model.FeeTypePercentage, Percentage: 1.5}, 12345, "IDR", 185},
+		{"percentage rounded to cents", model.FeeRule{Type: model.FeeTypePercentage, Percentage: 3.5}, 10.99, "PHP", 0.38},
+		{"first tier at its bound", tiered, 100000, "IDR", 3000},
+		{"middle tier", tiered, 500000, "IDR", 7500},
+		{"unbounded last tier", tiered, 2000000, "IDR", 20000},
+		{"unknown type", model.FeeRule{Type: "OTHER", FlatAmount: 100}, 1000, "IDR", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := calculateFee(tt.rule, tt.amount, tt.currency); got != tt.want {
+				t.Errorf("calculateFee = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"payment-broker/internal/helper"
+	model "payment-broker/internal/model/db"
+	"payment-broker/internal/model/dto"
+	"payment-broker/internal/repository"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type InvoiceService interface {
+	RecordInvoice(tenantID string, resp *dto.XenditResponse)
+	HandleCallback(tenantID string, body []byte) error
+	SyncInvoice(tenantID string, invoice dto.XenditInvoice) error
+}
+
+type invoiceService struct {
+	logger            *zap.Logger
+	invoiceRepository repository.InvoiceRepository
+}
+
+func NewInvoiceService(logger *zap.Logger, invoiceRepository repository.InvoiceRepository) InvoiceService {
+	return &invoiceService{
+		logger:            logger,
+		invoiceRepository: invoiceRepository,
+	}
+}
+
+// RecordInvoice stores the invoice returned by a successful create call.
+func (s *invoiceService) RecordInvoice(tenantID string, resp *dto.XenditResponse) {
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return
+	}
+
+	var invoice dto.XenditInvoice
+	if err := json.Unmarshal(resp.Body, &invoice); err != nil || invoice.ID == "" {
+		s.logger.Error("failed to parse invoice response", zap.String("tenant_id", tenantID), zap.Error(err))
+		return
+	}
+
+	if err := s.SyncInvoice(tenantID, invoice); err != nil {
+		s.logger.Error("invoiceService.SyncInvoice", zap.String("tenant_id", tenantID), zap.String("invoice_id", invoice.ID), zap.Error(err))
+	}
+}
+
+func (s *invoiceService) HandleCallback(tenantID string, body []byte) error {
+	var invoice dto.XenditInvoice
+	if err := json.Unmarshal(body, &invoice); err != nil || invoice.ID == "" {
+		return fmt.Errorf("invalid invoice callback: %w", err)
+	}
+	return s.SyncInvoice(tenantID, invoice)
+}
+
+// SyncInvoice stores the invoice state as Xendit reported it.
+func (s *invoiceService) SyncInvoice(tenantID string, invoice dto.XenditInvoice) error {
+	id, err := helper.ParseTenantID(tenantID)
+	if err != nil {
+		return err
+	}
+
+	var paidAt *time.Time
+	if t, err := time.Parse(time.RFC3339, invoice.PaidAt); err == nil {
+		paidAt = &t
+	}
+
+	return s.invoiceRepository.Upsert(&model.Invoice{
+		TenantID:       id,
+		InvoiceID:      invoice.ID,
+		ExternalID:     helper.StripTenantReference(invoice.ExternalID),
+		Currency:       invoice.Currency,
+		Amount:         invoice.Amount,
+		Status:         invoice.Status,
+		PaymentMethod:  invoice.PaymentMethod,
+		PaymentChannel: invoice.PaymentChannel,
+		PaidAt:         paidAt,
+	})
+}
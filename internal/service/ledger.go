@@ -0,0 +1,316 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"payment-broker/internal/helper"
+	model "payment-broker/internal/model/db"
+	"payment-broker/internal/model/dto"
+	"payment-broker/internal/repository"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// creditNormalAccounts are the ledger accounts whose balance grows with credits.
+var creditNormalAccounts = map[string]bool{
+	model.LedgerTenantReceivable:   true,
+	model.LedgerPlatformFeeRevenue: true,
+}
+
+type LedgerService interface {
+	HandleInvoiceCallback(tenantID string, body []byte) error
+	HandleWebhook(tenantID string, event string, data json.RawMessage) error
+	PostPayout(tenantID string, payout dto.XenditPayout) error
+	GetBalances(ctx context.Context, tenantID uint) (*dto.LedgerBalances, error)
+	GetEntries(tenantID uint, beforeID uint, limit int) ([]model.LedgerEntry, error)
+}
+
+type ledgerService struct {
+	logger           *zap.Logger
+	xenditService    XenditService
+	tenantRepository repository.TenantRepository
+	feeRepository    repository.FeeRepository
+	ledgerRepository repository.LedgerRepository
+}
+
+func NewLedgerService(logger *zap.Logger, xenditService XenditService, tenantRepository repository.TenantRepository,
+	feeRepository repository.FeeRepository, ledgerRepository repository.LedgerRepository) LedgerService {
+	return &ledgerService{
+		logger:           logger,
+		xenditService:    xenditService,
+		tenantRepository: tenantRepository,
+		feeRepository:    feeRepository,
+		ledgerRepository: ledgerRepository,
+	}
+}
+
+// HandleInvoiceCallback posts a paid invoice: the paid amount lands in the
+// Xendit balance and is split between the tenant and the platform fee recorded
+// by FeeService, so it must run after the fee is stored.
+func (s *ledgerService) HandleInvoiceCallback(tenantID string, body []byte) error {
+	var invoice dto.XenditInvoice
+	if err := json.Unmarshal(body, &invoice); err != nil {
+		return fmt.Errorf("invalid invoice callback: %w", err)
+	}
+
+	if invoice.Status != "PAID" && invoice.Status != "SETTLED" {
+		return nil
+	}
+	if invoice.ID == "" {
+		return fmt.Errorf("invoice callback has no id")
+	}
+
+	tid, err := helper.ParseTenantID(tenantID)
+	if err != nil {
+		return err
+	}
+
+	amount := invoice.PaidAmount
+	if amount == 0 {
+		amount = invoice.Amount
+	}
+
+	var fee float64
+	platformFee, err := s.feeRepository.FindInvoiceFee(invoice.ID)
+	if err != nil {
+		return err
+	}
+	if platformFee != nil {
+		fee = platformFee.Fee
+	}
+
+	return s.post(tid, "invoice.paid", invoice.ID, invoice.ExternalID, invoice.Currency,
+		debit(model.LedgerXenditBalance, amount),
+		credit(model.LedgerTenantReceivable, amount-fee),
+		credit(model.LedgerPlatformFeeRevenue, fee),
+	)
+}
+
+func (s *ledgerService) HandleWebhook(tenantID string, event string, data json.RawMessage) error {
+	switch {
+	case strings.HasPrefix(event, "payout."):
+		var payout dto.XenditPayout
+		err := json.Unmarshal(data, &payout)
+		if err := payloadError(err, payout.ID); err != nil {
+			s.logger.Warn("Skipping malformed payout ledger webhook", zap.String("tenant_id", tenantID), zap.String("event", event), zap.Error(err))
+			return nil
+		}
+		return s.PostPayout(tenantID, payout)
+	case strings.HasPrefix(event, "refund."):
+		var refund dto.XenditRefund
+		err := json.Unmarshal(data, &refund)
+		if err := payloadError(err, refund.ID); err != nil {
+			s.logger.Warn("Skipping malformed refund ledger webhook", zap.String("tenant_id", tenantID), zap.String("event", event), zap.Error(err))
+			return nil
+		}
+		return s.postRefund(tenantID, refund)
+	}
+	return nil
+}
+
+// PostPayout moves the payout amount out of the Xendit balance into payouts
+// in-flight the first time the payout is seen, then settles it against the
+// tenant once it succeeds or returns it to the balance when it fails.
+func (s *ledgerService) PostPayout(tenantID string, payout dto.XenditPayout) error {
+	tid, err := helper.ParseTenantID(tenantID)
+	if err != nil {
+		return err
+	}
+
+	post := func(event string, lines ...model.LedgerLine) error {
+		return s.post(tid, event, payout.ID, payout.ReferenceID, payout.Currency, lines...)
+	}
+
+	if err := post("payout.in_flight",
+		debit(model.LedgerPayoutsInFlight, payout.Amount),
+		credit(model.LedgerXenditBalance, payout.Amount),
+	); err != nil {
+		return err
+	}
+
+	switch payout.Status {
+	case "SUCCEEDED":
+		return post("payout.succeeded",
+			debit(model.LedgerTenantReceivable, payout.Amount),
+			credit(model.LedgerPayoutsInFlight, payout.Amount),
+		)
+	case "FAILED", "CANCELLED", "REVERSED":
+		// A payout reversed by the bank after it succeeded has already left
+		// in-flight, so the tenant is credited back instead.
+		succeeded, err := s.ledgerRepository.HasEntry(ledgerKey("payout.succeeded", payout.ID))
+		if err != nil {
+			return err
+		}
+		if succeeded {
+			return post("payout.reversed",
+				debit(model.LedgerXenditBalance, payout.Amount),
+				credit(model.LedgerTenantReceivable, payout.Amount),
+			)
+		}
+		return post("payout.released",
+			debit(model.LedgerXenditBalance, payout.Amount),
+			credit(model.LedgerPayoutsInFlight, payout.Amount),
+		)
+	}
+
+	return nil
+}
+
+// postRefund charges a succeeded refund to the tenant through the refunds
+// account, which Available nets against the receivable.
+func (s *ledgerService) postRefund(tenantID string, refund dto.XenditRefund) error {
+	if refund.Status != "SUCCEEDED" {
+		return nil
+	}
+
+	tid, err := helper.ParseTenantID(tenantID)
+	if err != nil {
+		return err
+	}
+
+	return s.post(tid, "refund.succeeded", refund.ID, refund.ReferenceID, refund.Currency,
+		debit(model.LedgerRefunds, refund.Amount),
+		credit(model.LedgerXenditBalance, refund.Amount),
+	)
+}
+
+// GetBalances totals the tenant's ledger per currency. When the tenant has a
+// sub-account, each currency is compared with Xendit's CASH balance; the
+// comparison is left out if Xendit can't be reached. Tenants sharing a
+// sub-account will each show the difference made by the others.
+func (s *ledgerService) GetBalances(ctx context.Context, tenantID uint) (*dto.LedgerBalances, error) {
+	tenant, err := s.tenantRepository.FindByID(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := s.ledgerRepository.FindTotals(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	balances := map[string]*dto.LedgerBalance{}
+	for _, total := range totals {
+		balance := balances[total.Currency]
+		if balance == nil {
+			balance = &dto.LedgerBalance{Currency: total.Currency, Accounts: map[string]float64{}}
+			balances[total.Currency] = balance
+		}
+
+		amount := total.Debit - total.Credit
+		if creditNormalAccounts[total.Account] {
+			amount = -amount
+		}
+		balance.Accounts[total.Account] = helper.RoundAmount(amount, total.Currency)
+	}
+
+	result := &dto.LedgerBalances{TenantID: tenantID, Balances: []dto.LedgerBalance{}}
+	for _, balance := range balances {
+		balance.Available = helper.RoundAmount(balance.Accounts[model.LedgerTenantReceivable]-balance.Accounts[model.LedgerRefunds], balance.Currency)
+
+		if tenant.AccountID != "" {
+			remote, err := s.fetchXenditBalance(ctx, tenant.AccountID, balance.Currency)
+			if err != nil {
+				s.logger.Error("ledgerService.fetchXenditBalance", zap.Uint("tenant_id", tenantID),
+					zap.String("currency", balance.Currency), zap.Error(err))
+			} else {
+				difference := helper.RoundAmount(remote-balance.Accounts[model.LedgerXenditBalance], balance.Currency)
+				balance.XenditBalance = &remote
+				balance.Difference = &difference
+			}
+		}
+
+		result.Balances = append(result.Balances, *balance)
+	}
+	sort.Slice(result.Balances, func(i, j int) bool { return result.Balances[i].Currency < result.Balances[j].Currency })
+
+	return result, nil
+}
+
+func (s *ledgerService) GetEntries(tenantID uint, beforeID uint, limit int) ([]model.LedgerEntry, error) {
+	return s.ledgerRepository.FindEntries(tenantID, beforeID, limit)
+}
+
+// post stores a balanced entry for event on resourceID. Posting the same event
+// for a resource again does nothing.
+func (s *ledgerService) post(tenantID uint, event, resourceID, reference, currency string, lines ...model.LedgerLine) error {
+	var debits, credits float64
+	entryLines := make([]model.LedgerLine, 0, len(lines))
+	for _, line := range lines {
+		line.Debit = helper.RoundAmount(line.Debit, currency)
+		line.Credit = helper.RoundAmount(line.Credit, currency)
+		if line.Debit == 0 && line.Credit == 0 {
+			continue
+		}
+
+		line.TenantID = tenantID
+		line.Currency = currency
+		debits += line.Debit
+		credits += line.Credit
+		entryLines = append(entryLines, line)
+	}
+
+	if helper.RoundAmount(debits-credits, currency) != 0 {
+		return fmt.Errorf("ledger entry %s for %s is unbalanced: debits %v, credits %v", event, resourceID, debits, credits)
+	}
+	if len(entryLines) == 0 {
+		return nil
+	}
+
+	created, err := s.ledgerRepository.CreateEntry(&model.LedgerEntry{
+		TenantID:   tenantID,
+		Key:        ledgerKey(event, resourceID),
+		Event:      event,
+		ResourceID: resourceID,
+		Reference:  helper.StripTenantReference(reference),
+		Currency:   currency,
+		Lines:      entryLines,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to post ledger entry: %w", err)
+	}
+
+	if created {
+		s.logger.Info("Ledger entry posted", zap.Uint("tenant_id", tenantID), zap.String("event", event),
+			zap.String("resource_id", resourceID))
+	}
+	return nil
+}
+
+func (s *ledgerService) fetchXenditBalance(ctx context.Context, accountID, currency string) (float64, error) {
+	query := url.Values{}
+	query.Set("account_type", "CASH")
+	query.Set("currency", currency)
+
+	resp, err := s.xenditService.GetRequest(ctx, "/balance", query, accountID)
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("xendit responded to /balance with status %d", resp.StatusCode)
+	}
+
+	var balance dto.XenditBalance
+	if err := json.Unmarshal(resp.Body, &balance); err != nil {
+		return 0, fmt.Errorf("invalid response from /balance: %w", err)
+	}
+
+	return balance.Balance, nil
+}
+
+func ledgerKey(event, resourceID string) string {
+	return event + ":" + resourceID
+}
+
+func debit(account string, amount float64) model.LedgerLine {
+	return model.LedgerLine{Account: account, Debit: amount}
+}
+
+func credit(account string, amount float64) model.LedgerLine {
+	return model.LedgerLine{Account: account, Credit: amount}
+}
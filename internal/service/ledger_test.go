@@ -0,0 +1,199 @@
+package service
+
+import (
+	"payment-broker/internal/helper"
+	model "payment-broker/internal/model/db"
+	"payment-broker/internal/model/dto"
+	"payment-broker/internal/repository"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+// memoryLedgerRepository keeps entries in memory so postings can be checked
+// without a database.
+type memoryLedgerRepository struct {
+	repository.LedgerRepository
+	entries []model.LedgerEntry
+}
+
+func (r *memoryLedgerRepository) CreateEntry(entry *model.LedgerEntry) (bool, error) {
+	if ok, _ := r.HasEntry(entry.Key); ok {
+		return false, nil
+	}
+	r.entries = append(r.entries, *entry)
+	return true, nil
+}
+
+func (r *memoryLedgerRepository) HasEntry(key string) (bool, error) {
+	for _, entry := range r.entries {
+		if entry.Key == key {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// balance returns the debit-normal balance of account across all entries.
+func (r *memoryLedgerRepository) balance(account string) float64 {
+	var total float64
+	for _, entry := range r.entries {
+		for _, line := range entry.Lines {
+			if line.Account == account {
+				total += line.Debit - line.Credit
+			}
+		}
+	}
+	return total
+}
+
+type memoryFeeRepository struct {
+	repository.FeeRepository
+	fees map[string]float64
+}
+
+func (r *memoryFeeRepository) FindInvoiceFee(invoiceID string) (*model.PlatformFee, error) {
+	fee, ok := r.fees[invoiceID]
+	if !ok {
+		return nil, nil
+	}
+	return &model.PlatformFee{Fee: fee}, nil
+}
+
+func newTestLedgerService(fees map[string]float64) (*ledgerService, *memoryLedgerRepository) {
+	ledger := &memoryLedgerRepository{}
+	return &ledgerService{
+		logger:           zap.NewNop(),
+		feeRepository:    &memoryFeeRepository{fees: fees},
+		ledgerRepository: ledger,
+	}, ledger
+}
+
+func assertBalanced(t *testing.T, entries []model.LedgerEntry) {
+	t.Helper()
+	for _, entry := range entries {
+		var debits, credits float64
+		for _, line := range entry.Lines {
+			debits += line.Debit
+			credits += line.Credit
+		}
+		if helper.RoundAmount(debits-credits, entry.Currency) != 0 {
+			t.Errorf("entry %s is unbalanced: debits %v, credits %v", entry.Key, debits, credits)
+		}
+	}
+}
+
+func TestLedgerPostRejectsUnbalancedEntries(t *testing.T) {
+	s, ledger := newTestLedgerService(nil)
+
+	err := s.post(1, "test", "res-1", "", "IDR",
+		debit(model.LedgerXenditBalance, 100),
+		credit(model.LedgerTenantReceivable, 90),
+	)
+	if err == nil {
+		t.Fatal("post() accepted an unbalanced entry")
+	}
+	if len(ledger.entries) != 0 {
+		t.Errorf("post() stored %d entries, want 0", len(ledger.entries))
+	}
+}
+
+func TestLedgerPostRoundsAndDropsEmptyLines(t *testing.T) {
+	s, ledger := newTestLedgerService(nil)
+
+	err := s.post(1, "test", "res-1", "", "USD",
+		debit(model.LedgerXenditBalance, 10.004),
+		credit(model.LedgerTenantReceivable, 10),
+		credit(model.LedgerPlatformFeeRevenue, 0),
+	)
+	if err != nil {
+		t.Fatalf("post() error = %v", err)
+	}
+	if len(ledger.entries) != 1 || len(ledger.entries[0].Lines) != 2 {
+		t.Fatalf("post() stored %+v, want one entry with two lines", ledger.entries)
+	}
+	assertBalanced(t, ledger.entries)
+}
+
+func TestLedgerInvoiceCallbackSplitsFee(t *testing.T) {
+	s, ledger := newTestLedgerService(map[string]float64{"inv-1": 2500})
+	body := []byte(`{"id":"inv-1","external_id":"order-1","status":"PAID","amount":100000,"paid_amount":100000,"currency":"IDR"}`)
+
+	for i := 0; i < 2; i++ {
+		if err := s.HandleInvoiceCallback("1", body); err != nil {
+			t.Fatalf("HandleInvoiceCallback() error = %v", err)
+		}
+	}
+
+	if len(ledger.entries) != 1 {
+		t.Fatalf("duplicate callback posted %d entries, want 1", len(ledger.entries))
+	}
+	assertBalanced(t, ledger.entries)
+
+	if got := ledger.balance(model.LedgerXenditBalance); got != 100000 {
+		t.Errorf("Xendit balance = %v, want 100000", got)
+	}
+	if got := -ledger.balance(model.LedgerTenantReceivable); got != 97500 {
+		t.Errorf("tenant receivable = %v, want 97500", got)
+	}
+	if got := -ledger.balance(model.LedgerPlatformFeeRevenue); got != 2500 {
+		t.Errorf("platform fee revenue = %v, want 2500", got)
+	}
+}
+
+func TestLedgerPayoutLifecycle(t *testing.T) {
+	tests := []struct {
+		name       string
+		statuses   []string
+		balance    float64
+		receivable float64
+	}{
+		{"pending", []string{"ACCEPTED"}, -50000, 0},
+		{"succeeded", []string{"ACCEPTED", "SUCCEEDED"}, -50000, 50000},
+		{"failed", []string{"ACCEPTED", "FAILED"}, 0, 0},
+		{"reversed after success", []string{"SUCCEEDED", "REVERSED"}, 0, 0},
+		{"repeated success", []string{"SUCCEEDED", "SUCCEEDED"}, -50000, 50000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, ledger := newTestLedgerService(nil)
+			for _, status := range tt.statuses {
+				payout := dto.XenditPayout{ID: "disb-1", ReferenceID: "ref-1", Currency: "IDR", Amount: 50000, Status: status}
+				if err := s.PostPayout("1", payout); err != nil {
+					t.Fatalf("PostPayout(%s) error = %v", status, err)
+				}
+			}
+
+			assertBalanced(t, ledger.entries)
+			if got := ledger.balance(model.LedgerXenditBalance); got != tt.balance {
+				t.Errorf("Xendit balance = %v, want %v", got, tt.balance)
+			}
+			if got := ledger.balance(model.LedgerTenantReceivable); got != tt.receivable {
+				t.Errorf("tenant receivable debits = %v, want %v", got, tt.receivable)
+			}
+			if got := ledger.balance(model.LedgerPayoutsInFlight); tt.name != "pending" && got != 0 {
+				t.Errorf("payouts in flight = %v, want 0", got)
+			}
+		})
+	}
+}
+
+func TestLedgerRefundChargesTenant(t *testing.T) {
+	s, ledger := newTestLedgerService(nil)
+
+	for _, status := range []string{"PENDING", "SUCCEEDED", "SUCCEEDED"} {
+		refund := dto.XenditRefund{ID: "rfd-1", Currency: "PHP", Amount: 150.5, Status: status}
+		if err := s.postRefund("1", refund); err != nil {
+			t.Fatalf("postRefund(%s) error = %v", status, err)
+		}
+	}
+
+	if len(ledger.entries) != 1 {
+		t.Fatalf("refund posted %d entries, want 1", len(ledger.entries))
+	}
+	assertBalanced(t, ledger.entries)
+	if got := ledger.balance(model.LedgerRefunds); got != 150.5 {
+		t.Errorf("refunds = %v, want 150.5", got)
+	}
+}
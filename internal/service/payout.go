@@ -0,0 +1,108 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"payment-broker/internal/helper"
+	model "payment-broker/internal/model/db"
+	"payment-broker/internal/model/dto"
+	"payment-broker/internal/repository"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+type PayoutService interface {
+	RecordPayout(tenantID string, resp *dto.XenditResponse)
+	HandleWebhook(tenantID string, event string, data json.RawMessage) error
+	GetPayout(tenantID string, payoutID string) (*model.Payout, error)
+	OwnsPayout(tenantID string, resp *dto.XenditResponse) bool
+	SyncPayout(tenantID string, payout dto.XenditPayout) error
+}
+
+type payoutService struct {
+	logger           *zap.Logger
+	payoutRepository repository.PayoutRepository
+}
+
+func NewPayoutService(logger *zap.Logger, payoutRepository repository.PayoutRepository) PayoutService {
+	return &payoutService{
+		logger:           logger,
+		payoutRepository: payoutRepository,
+	}
+}
+
+// RecordPayout stores the payout returned by a successful create or cancel call.
+func (s *payoutService) RecordPayout(tenantID string, resp *dto.XenditResponse) {
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return
+	}
+
+	var payout dto.XenditPayout
+	if err := json.Unmarshal(resp.Body, &payout); err != nil || payout.ID == "" {
+		s.logger.Error("failed to parse payout response", zap.String("tenant_id", tenantID), zap.Error(err))
+		return
+	}
+
+	if err := s.SyncPayout(tenantID, payout); err != nil {
+		s.logger.Error("payoutService.SyncPayout", zap.String("tenant_id", tenantID), zap.String("payout_id", payout.ID), zap.Error(err))
+	}
+}
+
+func (s *payoutService) HandleWebhook(tenantID string, event string, data json.RawMessage) error {
+	if !strings.HasPrefix(event, "payout.") {
+		return nil
+	}
+
+	var payout dto.XenditPayout
+	err := json.Unmarshal(data, &payout)
+	if err := payloadError(err, payout.ID); err != nil {
+		s.logger.Warn("Skipping malformed payout webhook", zap.String("tenant_id", tenantID),
+			zap.String("event", event), zap.Error(err))
+		return nil
+	}
+
+	if payout.Status == "FAILED" {
+		s.logger.Info("payout failed", zap.String("tenant_id", tenantID), zap.String("payout_id", payout.ID),
+			zap.String("failure_code", payout.FailureCode))
+	}
+
+	return s.SyncPayout(tenantID, payout)
+}
+
+func (s *payoutService) GetPayout(tenantID string, payoutID string) (*model.Payout, error) {
+	id, err := helper.ParseTenantID(tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return s.payoutRepository.Find(id, payoutID)
+}
+
+// OwnsPayout checks a Xendit payout response against the tenant's reference
+// prefix, since several tenants may share one sub-account.
+func (s *payoutService) OwnsPayout(tenantID string, resp *dto.XenditResponse) bool {
+	var payout dto.XenditPayout
+	if err := json.Unmarshal(resp.Body, &payout); err != nil {
+		return false
+	}
+	return strings.HasPrefix(payout.ReferenceID, tenantID+":")
+}
+
+// SyncPayout stores the payout state as Xendit reported it.
+func (s *payoutService) SyncPayout(tenantID string, payout dto.XenditPayout) error {
+	id, err := helper.ParseTenantID(tenantID)
+	if err != nil {
+		return err
+	}
+
+	return s.payoutRepository.Upsert(&model.Payout{
+		TenantID:    id,
+		PayoutID:    payout.ID,
+		ReferenceID: helper.StripTenantReference(payout.ReferenceID),
+		ChannelCode: payout.ChannelCode,
+		Currency:    payout.Currency,
+		Amount:      payout.Amount,
+		Status:      payout.Status,
+		FailureCode: payout.FailureCode,
+	})
+}
@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"payment-broker/internal/helper"
+	model "payment-broker/internal/model/db"
+	"payment-broker/internal/model/dto"
+	"payment-broker/internal/repository"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"go.uber.org/zap"
+)
+
+type PayoutApprovalService interface {
+	RequiresApproval(tenantID uint, amount float64, currency string) (bool, error)
+	Submit(ctx context.Context, tenantID uint, requestedBy string, note string, request *dto.PayoutRequest, data map[string]interface{}) (*model.PayoutApproval, error)
+	GetApprovals(status string) ([]model.PayoutApproval, error)
+	GetApproval(id uint) (*model.PayoutApproval, error)
+	GetTenantApproval(tenantID string, id uint) (*model.PayoutApproval, error)
+	GetHistory(id uint) ([]model.PayoutApprovalEvent, error)
+	Approve(ctx context.Context, id uint, approver string) (*model.PayoutApproval, *dto.XenditResponse, error)
+	Reject(id uint, approver string, reason string) (*model.PayoutApproval, error)
+}
+
+type payoutApprovalService struct {
+	logger                   *zap.Logger
+	resty                    *resty.Client
+	notifyURL                string
+	xenditService            XenditService
+	payoutService            PayoutService
+	payoutLimitService       PayoutLimitService
+	tenantRepository         repository.TenantRepository
+	payoutApprovalRepository repository.PayoutApprovalRepository
+}
+
+func NewPayoutApprovalService(logger *zap.Logger, resty *resty.Client, xenditService XenditService, payoutService PayoutService,
+	payoutLimitService PayoutLimitService, tenantRepository repository.TenantRepository,
+	payoutApprovalRepository repository.PayoutApprovalRepository) PayoutApprovalService {
+	return &payoutApprovalService{
+		logger:                   logger,
+		resty:                    resty,
+		notifyURL:                os.Getenv("PAYOUT_APPROVAL_WEBHOOK_URL"),
+		xenditService:            xenditService,
+		payoutService:            payoutService,
+		payoutLimitService:       payoutLimitService,
+		tenantRepository:         tenantRepository,
+		payoutApprovalRepository: payoutApprovalRepository,
+	}
+}
+
+func (s *payoutApprovalService) RequiresApproval(tenantID uint, amount float64, currency string) (bool, error) {
+	limit, err := s.payoutLimitService.GetLimit(tenantID)
+	if err != nil {
+		return false, err
+	}
+
+	currencyLimit := findCurrencyLimit(limit, currency)
+	return currencyLimit != nil && currencyLimit.ApprovalThreshold > 0 && amount >= currencyLimit.ApprovalThreshold, nil
+}
+
+// Submit holds the payout for review instead of sending it to Xendit. The note
+// tells reviewers why it was held.
+func (s *payoutApprovalService) Submit(ctx context.Context, tenantID uint, requestedBy string, note string, request *dto.PayoutRequest,
+	data map[string]interface{}) (*model.PayoutApproval, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode payout payload: %w", err)
+	}
+
+	approval := &model.PayoutApproval{
+		TenantID:    tenantID,
+		ReferenceID: request.ReferenceID,
+		ChannelCode: request.ChannelCode,
+		Currency:    request.Currency,
+		Amount:      request.Amount,
+		Payload:     string(payload),
+		Status:      model.PayoutApprovalPending,
+		RequestedBy: requestedBy,
+	}
+
+	err = s.payoutApprovalRepository.Create(approval, &model.PayoutApprovalEvent{
+		Action: "REQUESTED",
+		Actor:  requestedBy,
+		Note:   note,
+	})
+	if err != nil {
+		s.logger.Error("payoutApprovalRepository.Create", zap.Uint("tenant_id", tenantID), zap.Error(err))
+		return nil, fmt.Errorf("failed to store payout approval: %w", err)
+	}
+
+	s.notify(ctx, approval)
+	return approval, nil
+}
+
+func (s *payoutApprovalService) GetApprovals(status string) ([]model.PayoutApproval, error) {
+	return s.payoutApprovalRepository.FindByStatus(status)
+}
+
+func (s *payoutApprovalService) GetApproval(id uint) (*model.PayoutApproval, error) {
+	return s.payoutApprovalRepository.Find(id)
+}
+
+func (s *payoutApprovalService) GetTenantApproval(tenantID string, id uint) (*model.PayoutApproval, error) {
+	tid, err := helper.ParseTenantID(tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return s.payoutApprovalRepository.FindForTenant(tid, id)
+}
+
+func (s *payoutApprovalService) GetHistory(id uint) ([]model.PayoutApprovalEvent, error) {
+	return s.payoutApprovalRepository.FindEvents(id)
+}
+
+// Approve claims the approval, applies the tenant's payout limits and sends the
+// stored payload to Xendit. The requester can't approve their own payout.
+func (s *payoutApprovalService) Approve(ctx context.Context, id uint, approver string) (*model.PayoutApproval, *dto.XenditResponse, error) {
+	approval, err := s.checkReviewer(id, approver)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	err = s.payoutApprovalRepository.Transition(id, model.PayoutApprovalPending, model.PayoutApprovalProcessing,
+		map[string]interface{}{"reviewed_by": approver, "reviewed_at": now},
+		&model.PayoutApprovalEvent{Action: "APPROVED", Actor: approver})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, sendErr := s.send(ctx, approval)
+	if sendErr == nil && resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		// Xendit accepted the payout, so it is approved even when its ID can't
+		// be read, and the history says the ID is missing.
+		var payout dto.XenditPayout
+		var note string
+		if err := json.Unmarshal(resp.Body, &payout); err != nil {
+			s.logger.Error("Failed to decode Xendit payout response", zap.Uint("id", id), zap.ByteString("body", resp.Body), zap.Error(err))
+			note = "payout ID missing, Xendit's response could not be decoded"
+		} else {
+			note = payout.ID
+		}
+
+		err = s.payoutApprovalRepository.Transition(id, model.PayoutApprovalProcessing, model.PayoutApprovalApproved,
+			map[string]interface{}{"payout_id": payout.ID},
+			&model.PayoutApprovalEvent{Action: "SENT", Actor: "system", Note: note})
+		if err != nil {
+			s.logger.Error("payoutApprovalRepository.Transition", zap.Uint("id", id), zap.Error(err))
+		}
+
+		approval, _ = s.payoutApprovalRepository.Find(id)
+		return approval, resp, nil
+	}
+
+	var reason string
+	if sendErr != nil {
+		reason = sendErr.Error()
+	} else {
+		reason = fmt.Sprintf("xendit responded with status %d", resp.StatusCode)
+	}
+
+	err = s.payoutApprovalRepository.Transition(id, model.PayoutApprovalProcessing, model.PayoutApprovalFailed,
+		map[string]interface{}{"reason": reason},
+		&model.PayoutApprovalEvent{Action: "FAILED", Actor: "system", Note: reason})
+	if err != nil {
+		s.logger.Error("payoutApprovalRepository.Transition", zap.Uint("id", id), zap.Error(err))
+	}
+
+	approval, _ = s.payoutApprovalRepository.Find(id)
+	if sendErr != nil {
+		return approval, nil, sendErr
+	}
+	return approval, resp, nil
+}
+
+func (s *payoutApprovalService) Reject(id uint, approver string, reason string) (*model.PayoutApproval, error) {
+	if _, err := s.checkReviewer(id, approver); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	err := s.payoutApprovalRepository.Transition(id, model.PayoutApprovalPending, model.PayoutApprovalRejected,
+		map[string]interface{}{"reviewed_by": approver, "reviewed_at": now, "reason": reason},
+		&model.PayoutApprovalEvent{Action: "REJECTED", Actor: approver, Note: reason})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.payoutApprovalRepository.Find(id)
+}
+
+func (s *payoutApprovalService) checkReviewer(id uint, approver string) (*model.PayoutApproval, error) {
+	if approver == "" {
+		return nil, fmt.Errorf("reviews require a personal admin key from ADMIN_API_KEYS")
+	}
+
+	approval, err := s.payoutApprovalRepository.Find(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if approval.RequestedBy == approver {
+		return nil, fmt.Errorf("payout must be reviewed by someone other than the requester")
+	}
+
+	if approval.Status != model.PayoutApprovalPending {
+		return nil, fmt.Errorf("payout approval is already %s", approval.Status)
+	}
+
+	return approval, nil
+}
+
+func (s *payoutApprovalService) send(ctx context.Context, approval *model.PayoutApproval) (*dto.XenditResponse, error) {
+	tenant, err := s.tenantRepository.FindByID(approval.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("tenant not found: %w", err)
+	}
+
+	reservation, err := s.payoutLimitService.Reserve(ctx, approval.TenantID, approval.Amount, approval.Currency, approval.ChannelCode)
+	if err != nil {
+		var limitErr *PayoutLimitError
+		if errors.As(err, &limitErr) {
+			return nil, fmt.Errorf("%s: %s", limitErr.Code, limitErr.Message)
+		}
+		return nil, err
+	}
+
+	resp, err := s.xenditService.CreateRequest(ctx, json.RawMessage(approval.Payload), "/v2/payouts", tenant.AccountID, "")
+	if err != nil || resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		s.payoutLimitService.Release(ctx, reservation)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.payoutService.RecordPayout(fmt.Sprintf("%d", approval.TenantID), resp)
+	return resp, nil
+}
+
+// notify tells approvers about a pending payout through PAYOUT_APPROVAL_WEBHOOK_URL,
+// such as a Slack incoming webhook. Without it the request is only logged.
+func (s *payoutApprovalService) notify(ctx context.Context, approval *model.PayoutApproval) {
+	text := fmt.Sprintf("Payout approval #%d: tenant %d requests %.2f %s to %s (requested by %s)",
+		approval.ID, approval.TenantID, approval.Amount, approval.Currency, approval.ChannelCode, approval.RequestedBy)
+
+	if s.notifyURL == "" || s.resty == nil {
+		s.logger.Info("payout approval requested", zap.Uint("approval_id", approval.ID), zap.String("summary", text))
+		return
+	}
+
+	resp, err := s.resty.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/json").
+		SetBody(map[string]interface{}{
+			"text":     text,
+			"approval": approval,
+		}).
+		Post(s.notifyURL)
+
+	if err != nil || resp.IsError() {
+		s.logger.Error("failed to notify payout approvers", zap.Uint("approval_id", approval.ID), zap.Error(err))
+	}
+}
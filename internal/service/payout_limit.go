@@ -0,0 +1,300 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"payment-broker/internal/lib"
+	model "payment-broker/internal/model/db"
+	"payment-broker/internal/model/dto"
+	"payment-broker/internal/repository"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	PayoutLimitSingleAmount  = "PAYOUT_SINGLE_AMOUNT_EXCEEDED"
+	PayoutLimitDailyAmount   = "PAYOUT_DAILY_AMOUNT_EXCEEDED"
+	PayoutLimitMonthlyAmount = "PAYOUT_MONTHLY_AMOUNT_EXCEEDED"
+	PayoutLimitHourlyCount   = "PAYOUT_HOURLY_COUNT_EXCEEDED"
+	PayoutLimitChannel       = "PAYOUT_CHANNEL_NOT_ALLOWED"
+	PayoutLimitCurrency      = "PAYOUT_CURRENCY_NOT_ALLOWED"
+)
+
+// PayoutLimitError is returned when a tenant payout limit blocks a request.
+type PayoutLimitError struct {
+	Code    string
+	Message string
+}
+
+func (e *PayoutLimitError) Error() string {
+	return e.Message
+}
+
+// PayoutReservation is the share of the tenant's counters taken by one payout.
+// It must be released when the payout isn't accepted by Xendit.
+type PayoutReservation struct {
+	keys   []string
+	amount float64
+}
+
+// reservePayoutScript checks every counter before touching any of them, so a
+// payout either counts against all windows or none, even across replicas.
+//
+// KEYS: hourly count, daily amount, monthly amount
+// ARGV: amount, hourly limit, daily limit, monthly limit, hourly/daily/monthly TTL
+var reservePayoutScript = redis.NewScript(`
+local amount = tonumber(ARGV[1])
+local hourly = tonumber(ARGV[2])
+local daily = tonumber(ARGV[3])
+local monthly = tonumber(ARGV[4])
+
+if hourly > 0 and tonumber(redis.call('GET', KEYS[1]) or '0') + 1 > hourly then
+	return 1
+end
+if daily > 0 and tonumber(redis.call('GET', KEYS[2]) or '0') + amount > daily then
+	return 2
+end
+if monthly > 0 and tonumber(redis.call('GET', KEYS[3]) or '0') + amount > monthly then
+	return 3
+end
+
+redis.call('INCR', KEYS[1])
+redis.call('EXPIRE', KEYS[1], ARGV[5])
+redis.call('INCRBYFLOAT', KEYS[2], ARGV[1])
+redis.call('EXPIRE', KEYS[2], ARGV[6])
+redis.call('INCRBYFLOAT', KEYS[3], ARGV[1])
+redis.call('EXPIRE', KEYS[3], ARGV[7])
+return 0
+`)
+
+var releasePayoutScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 1 then redis.call('DECR', KEYS[1]) end
+if redis.call('EXISTS', KEYS[2]) == 1 then redis.call('INCRBYFLOAT', KEYS[2], -tonumber(ARGV[1])) end
+if redis.call('EXISTS', KEYS[3]) == 1 then redis.call('INCRBYFLOAT', KEYS[3], -tonumber(ARGV[1])) end
+return 0
+`)
+
+type PayoutLimitService interface {
+	GetLimit(tenantID uint) (*model.PayoutLimit, error)
+	SetLimit(tenantID uint, body dto.PayoutLimitRequest) (*model.PayoutLimit, error)
+	Check(tenantID uint, amount float64, currency string, channelCode string) error
+	Reserve(ctx context.Context, tenantID uint, amount float64, currency string, channelCode string) (*PayoutReservation, error)
+	Release(ctx context.Context, reservation *PayoutReservation)
+}
+
+type payoutLimitService struct {
+	logger                *zap.Logger
+	redisLib              lib.RedisLib
+	tenantRepository      repository.TenantRepository
+	payoutLimitRepository repository.PayoutLimitRepository
+}
+
+func NewPayoutLimitService(logger *zap.Logger, redisLib lib.RedisLib, tenantRepository repository.TenantRepository,
+	payoutLimitRepository repository.PayoutLimitRepository) PayoutLimitService {
+	return &payoutLimitService{
+		logger:                logger,
+		redisLib:              redisLib,
+		tenantRepository:      tenantRepository,
+		payoutLimitRepository: payoutLimitRepository,
+	}
+}
+
+func (s *payoutLimitService) GetLimit(tenantID uint) (*model.PayoutLimit, error) {
+	limit, err := s.payoutLimitRepository.Find(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit == nil {
+		return &model.PayoutLimit{TenantID: tenantID, Currencies: []model.PayoutCurrencyLimit{}}, nil
+	}
+	return limit, nil
+}
+
+func (s *payoutLimitService) SetLimit(tenantID uint, body dto.PayoutLimitRequest) (*model.PayoutLimit, error) {
+	if errs := body.Validate(); len(errs) > 0 {
+		return nil, fmt.Errorf("%s %s", errs[0].Field, errs[0].Message)
+	}
+
+	if _, err := s.tenantRepository.FindByID(tenantID); err != nil {
+		return nil, fmt.Errorf("tenant not found: %w", err)
+	}
+
+	channels := make([]string, 0, len(body.AllowedChannels))
+	for _, channel := range body.AllowedChannels {
+		if channel = strings.ToUpper(strings.TrimSpace(channel)); channel != "" {
+			channels = append(channels, channel)
+		}
+	}
+
+	currencies := make([]model.PayoutCurrencyLimit, 0, len(body.Currencies))
+	for _, currency := range body.Currencies {
+		currencies = append(currencies, model.PayoutCurrencyLimit{
+			Currency:          currency.Currency,
+			MaxSingleAmount:   currency.MaxSingleAmount,
+			DailyAmount:       currency.DailyAmount,
+			MonthlyAmount:     currency.MonthlyAmount,
+			ApprovalThreshold: currency.ApprovalThreshold,
+		})
+	}
+
+	limit := &model.PayoutLimit{
+		TenantID:          tenantID,
+		HourlyCount:       body.HourlyCount,
+		AllowedChannels:   strings.Join(channels, ","),
+		Currencies:        currencies,
+		BeneficiaryPolicy: body.BeneficiaryPolicy,
+	}
+
+	if err := s.payoutLimitRepository.Upsert(limit); err != nil {
+		s.logger.Error("payoutLimitRepository.Upsert", zap.Uint("tenant_id", tenantID), zap.Error(err))
+		return nil, fmt.Errorf("failed to save payout limits: %w", err)
+	}
+
+	return limit, nil
+}
+
+// Check applies the tenant's limits that don't depend on earlier payouts, the
+// channel and currency allow-lists and the single payout limit, without
+// counting the payout.
+// It runs before a payout is held for approval so one that can never be sent
+// is refused straight away.
+func (s *payoutLimitService) Check(tenantID uint, amount float64, currency string, channelCode string) error {
+	limit, err := s.payoutLimitRepository.Find(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to load payout limits: %w", err)
+	}
+	return checkStaticLimits(limit, amount, currency, channelCode)
+}
+
+func checkStaticLimits(limit *model.PayoutLimit, amount float64, currency string, channelCode string) error {
+	if limit == nil {
+		return nil
+	}
+
+	if limit.AllowedChannels != "" && !containsFold(strings.Split(limit.AllowedChannels, ","), channelCode) {
+		return &PayoutLimitError{
+			Code:    PayoutLimitChannel,
+			Message: fmt.Sprintf("channel %s is not allowed for this tenant", channelCode),
+		}
+	}
+
+	if len(limit.Currencies) == 0 {
+		return nil
+	}
+
+	currencyLimit := findCurrencyLimit(limit, currency)
+	if currencyLimit == nil {
+		return &PayoutLimitError{
+			Code:    PayoutLimitCurrency,
+			Message: fmt.Sprintf("payouts in %s are not allowed for this tenant", currency),
+		}
+	}
+
+	if currencyLimit.MaxSingleAmount > 0 && amount > currencyLimit.MaxSingleAmount {
+		return &PayoutLimitError{
+			Code:    PayoutLimitSingleAmount,
+			Message: fmt.Sprintf("payout amount exceeds the single payout limit of %.2f %s", currencyLimit.MaxSingleAmount, currency),
+		}
+	}
+
+	return nil
+}
+
+// findCurrencyLimit returns nil when the tenant has no limits for currency.
+func findCurrencyLimit(limit *model.PayoutLimit, currency string) *model.PayoutCurrencyLimit {
+	if limit == nil {
+		return nil
+	}
+	for i := range limit.Currencies {
+		if strings.EqualFold(limit.Currencies[i].Currency, currency) {
+			return &limit.Currencies[i]
+		}
+	}
+	return nil
+}
+
+// Reserve applies the tenant's payout limits and counts the payout against its
+// hourly, daily and monthly windows. Windows are fixed UTC buckets. The hourly
+// count covers every currency, while each currency has its own amount counters
+// so amounts in different currencies aren't summed.
+func (s *payoutLimitService) Reserve(ctx context.Context, tenantID uint, amount float64, currency string, channelCode string) (*PayoutReservation, error) {
+	limit, err := s.payoutLimitRepository.Find(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load payout limits: %w", err)
+	}
+
+	if limit == nil {
+		return &PayoutReservation{}, nil
+	}
+
+	if err := checkStaticLimits(limit, amount, currency, channelCode); err != nil {
+		return nil, err
+	}
+
+	var daily, monthly float64
+	if currencyLimit := findCurrencyLimit(limit, currency); currencyLimit != nil {
+		daily, monthly = currencyLimit.DailyAmount, currencyLimit.MonthlyAmount
+	}
+	if limit.HourlyCount == 0 && daily == 0 && monthly == 0 {
+		return &PayoutReservation{}, nil
+	}
+
+	now := time.Now().UTC()
+	prefix := fmt.Sprintf("payout_limit:{%d}", tenantID)
+	keys := []string{
+		fmt.Sprintf("%s:hour:%s", prefix, now.Format("2006010215")),
+		fmt.Sprintf("%s:%s:day:%s", prefix, currency, now.Format("20060102")),
+		fmt.Sprintf("%s:%s:month:%s", prefix, currency, now.Format("200601")),
+	}
+
+	res, err := s.redisLib.Run(ctx, reservePayoutScript, keys,
+		amount, limit.HourlyCount, daily, monthly,
+		int((2 * time.Hour).Seconds()), int((48 * time.Hour).Seconds()), int((32 * 24 * time.Hour).Seconds()))
+	if err != nil {
+		s.logger.Error("redisLib.Run reservePayoutScript", zap.Uint("tenant_id", tenantID), zap.Error(err))
+		return nil, fmt.Errorf("failed to check payout limits: %w", err)
+	}
+
+	switch res.(int64) {
+	case 1:
+		return nil, &PayoutLimitError{
+			Code:    PayoutLimitHourlyCount,
+			Message: fmt.Sprintf("tenant reached the limit of %d payouts per hour", limit.HourlyCount),
+		}
+	case 2:
+		return nil, &PayoutLimitError{
+			Code:    PayoutLimitDailyAmount,
+			Message: fmt.Sprintf("payout would exceed the daily limit of %.2f %s", daily, currency),
+		}
+	case 3:
+		return nil, &PayoutLimitError{
+			Code:    PayoutLimitMonthlyAmount,
+			Message: fmt.Sprintf("payout would exceed the monthly limit of %.2f %s", monthly, currency),
+		}
+	}
+
+	return &PayoutReservation{keys: keys, amount: amount}, nil
+}
+
+func (s *payoutLimitService) Release(ctx context.Context, reservation *PayoutReservation) {
+	if reservation == nil || len(reservation.keys) == 0 {
+		return
+	}
+
+	if _, err := s.redisLib.Run(ctx, releasePayoutScript, reservation.keys, reservation.amount); err != nil {
+		s.logger.Error("redisLib.Run releasePayoutScript", zap.Strings("keys", reservation.keys), zap.Error(err))
+	}
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"payment-broker/internal/lib"
+	model "payment-broker/internal/model/db"
+	"payment-broker/internal/repository"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// memoryRedis runs reservePayoutScript against counters kept in memory.
+type memoryRedis struct {
+	lib.RedisLib
+	counters map[string]float64
+}
+
+func (r *memoryRedis) Run(ctx context.Context, script *redis.Script, keys []string, args ...interface{}) (interface{}, error) {
+	if script != reservePayoutScript {
+		return nil, errors.New("unexpected script")
+	}
+
+	amount := args[0].(float64)
+	hourly := args[1].(int)
+	daily, monthly := args[2].(float64), args[3].(float64)
+
+	switch {
+	case hourly > 0 && r.counters[keys[0]]+1 > float64(hourly):
+		return int64(1), nil
+	case daily > 0 && r.counters[keys[1]]+amount > daily:
+		return int64(2), nil
+	case monthly > 0 && r.counters[keys[2]]+amount > monthly:
+		return int64(3), nil
+	}
+
+	r.counters[keys[0]]++
+	r.counters[keys[1]] += amount
+	r.counters[keys[2]] += amount
+	return int64(0), nil
+}
+
+type memoryPayoutLimitRepository struct {
+	repository.PayoutLimitRepository
+	limit *model.PayoutLimit
+}
+
+func (r *memoryPayoutLimitRepository) Find(tenantID uint) (*model.PayoutLimit, error) {
+	return r.limit, nil
+}
+
+func TestCheckStaticLimits(t *testing.T) {
+	limit := &model.PayoutLimit{
+		AllowedChannels: "ID_BCA,PH_GCASH",
+		Currencies: []model.PayoutCurrencyLimit{
+			{Currency: "IDR", MaxSingleAmount: 10000000},
+			{Currency: "PHP", MaxSingleAmount: 50000},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		limit    *model.PayoutLimit
+		amount   float64
+		currency string
+		channel  string
+		want     string
+	}{
+		{"no limits", nil, 1e12, "USD", "ID_BCA", ""},
+		{"within currency limit", limit, 10000000, "IDR", "ID_BCA", ""},
+		{"limit is per currency", limit, 60000, "PHP", "PH_GCASH", PayoutLimitSingleAmount},
+		{"unconfigured currency", limit, 1, "USD", "ID_BCA", PayoutLimitCurrency},
+		{"channel not allowed", limit, 1, "IDR", "ID_BRI", PayoutLimitChannel},
+		{"no currencies configured", &model.PayoutLimit{HourlyCount: 5}, 1e12, "USD", "ID_BCA", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkStaticLimits(tt.limit, tt.amount, tt.currency, tt.channel)
+
+			var limitErr *PayoutLimitError
+			switch {
+			case tt.want == "" && err != nil:
+				t.Errorf("checkStaticLimits() error = %v, want nil", err)
+			case tt.want != "" && !errors.As(err, &limitErr):
+				t.Errorf("checkStaticLimits() error = %v, want %s", err, tt.want)
+			case tt.want != "" && limitErr.Code != tt.want:
+				t.Errorf("checkStaticLimits() code = %s, want %s", limitErr.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestReserveCountsHourlyAcrossCurrencies(t *testing.T) {
+	s := &payoutLimitService{
+		logger:   zap.NewNop(),
+		redisLib: &memoryRedis{counters: map[string]float64{}},
+		payoutLimitRepository: &memoryPayoutLimitRepository{limit: &model.PayoutLimit{
+			HourlyCount: 1,
+			Currencies: []model.PayoutCurrencyLimit{
+				{Currency: "IDR", DailyAmount: 10000000},
+				{Currency: "PHP", DailyAmount: 50000},
+			},
+		}},
+	}
+
+	if _, err := s.Reserve(context.Background(), 1, 100000, "IDR", "ID_BCA"); err != nil {
+		t.Fatalf("Reserve(IDR) error = %v", err)
+	}
+
+	_, err := s.Reserve(context.Background(), 1, 1000, "PHP", "PH_GCASH")
+	var limitErr *PayoutLimitError
+	if !errors.As(err, &limitErr) || limitErr.Code != PayoutLimitHourlyCount {
+		t.Errorf("Reserve(PHP) error = %v, want %s", err, PayoutLimitHourlyCount)
+	}
+}
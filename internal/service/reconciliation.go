@@ -0,0 +1,557 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"payment-broker/internal/helper"
+	"payment-broker/internal/lib"
+	model "payment-broker/internal/model/db"
+	"payment-broker/internal/model/dto"
+	"payment-broker/internal/repository"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	reconciliationPageSize = 100
+	reconciliationMaxPages = 100
+	// reconciliationLag keeps the newest transactions out of scheduled runs,
+	// since their webhooks may still be on the way.
+	reconciliationLag = 15 * time.Minute
+
+	// reconciliationLockKey is held in Redis while a run is in progress so
+	// only one replica reconciles at a time. The run keeps extending it, and
+	// it expires after reconciliationLockTTL when the replica dies.
+	reconciliationLockKey = "reconciliation:lock"
+	reconciliationLockTTL = time.Minute
+)
+
+// errReconciliationTruncated marks a run as partial when a tenant has more
+// pages than reconciliationMaxPages, since the rest of the window went unchecked.
+var errReconciliationTruncated = fmt.Errorf("stopped after %d pages, the rest of the window was not checked", reconciliationMaxPages)
+
+// extendLockScript and releaseLockScript only touch the lock while it still
+// holds the caller's token, so an expired lock taken by another replica is
+// left alone.
+//
+// KEYS: lock
+// ARGV: token, TTL in milliseconds
+var extendLockScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+var releaseLockScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+type ReconciliationService interface {
+	Begin(from, to time.Time, selfHeal bool) (*model.ReconciliationRun, error)
+	Execute(ctx context.Context, run *model.ReconciliationRun)
+	StartScheduler(ctx context.Context)
+	GetRuns(limit int) ([]model.ReconciliationRun, error)
+	GetRun(id uint) (*model.ReconciliationRun, []model.ReconciliationMismatch, error)
+}
+
+type reconciliationService struct {
+	logger                   *zap.Logger
+	redisLib                 lib.RedisLib
+	mu                       sync.Mutex
+	lockToken                string
+	xenditService            XenditService
+	invoiceService           InvoiceService
+	payoutService            PayoutService
+	feeService               FeeService
+	ledgerService            LedgerService
+	tenantRepository         repository.TenantRepository
+	invoiceRepository        repository.InvoiceRepository
+	payoutRepository         repository.PayoutRepository
+	reconciliationRepository repository.ReconciliationRepository
+}
+
+func NewReconciliationService(logger *zap.Logger, redisLib lib.RedisLib, xenditService XenditService, invoiceService InvoiceService, payoutService PayoutService,
+	feeService FeeService, ledgerService LedgerService, tenantRepository repository.TenantRepository, invoiceRepository repository.InvoiceRepository,
+	payoutRepository repository.PayoutRepository, reconciliationRepository repository.ReconciliationRepository) ReconciliationService {
+	return &reconciliationService{
+		logger:                   logger,
+		redisLib:                 redisLib,
+		xenditService:            xenditService,
+		invoiceService:           invoiceService,
+		payoutService:            payoutService,
+		feeService:               feeService,
+		ledgerService:            ledgerService,
+		tenantRepository:         tenantRepository,
+		invoiceRepository:        invoiceRepository,
+		payoutRepository:         payoutRepository,
+		reconciliationRepository: reconciliationRepository,
+	}
+}
+
+// Begin records a new run. Only one run can be in progress at a time across
+// every replica, and the caller must pass the run to Execute.
+func (s *reconciliationService) Begin(from, to time.Time, selfHeal bool) (*model.ReconciliationRun, error) {
+	if !from.Before(to) {
+		return nil, fmt.Errorf("reconciliation window must start before it ends")
+	}
+
+	token, err := lockToken()
+	if err != nil {
+		return nil, err
+	}
+
+	locked, err := s.redisLib.SetNX(context.Background(), reconciliationLockKey, token, reconciliationLockTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock reconciliation: %w", err)
+	}
+	if !locked {
+		return nil, fmt.Errorf("a reconciliation run is already in progress")
+	}
+
+	run := &model.ReconciliationRun{
+		WindowStart: from.UTC(),
+		WindowEnd:   to.UTC(),
+		SelfHeal:    selfHeal,
+		Status:      model.ReconciliationRunning,
+		StartedAt:   time.Now(),
+	}
+	if err := s.reconciliationRepository.CreateRun(run); err != nil {
+		s.releaseLock(token)
+		return nil, fmt.Errorf("failed to store reconciliation run: %w", err)
+	}
+
+	s.mu.Lock()
+	s.lockToken = token
+	s.mu.Unlock()
+
+	return run, nil
+}
+
+// Execute checks every tenant and stores the mismatches found. A tenant that
+// can't be checked is noted on the run and doesn't stop the others.
+func (s *reconciliationService) Execute(ctx context.Context, run *model.ReconciliationRun) {
+	s.mu.Lock()
+	token := s.lockToken
+	s.lockToken = ""
+	s.mu.Unlock()
+
+	defer s.holdLock(token)()
+
+	var failures []string
+
+	tenants, err := s.tenantRepository.FindAll()
+	if err != nil {
+		failures = append(failures, err.Error())
+	}
+
+	for _, tenant := range tenants {
+		if tenant.AccountID == "" {
+			continue
+		}
+
+		if err := s.reconcileInvoices(ctx, run, tenant); err != nil {
+			failures = append(failures, fmt.Sprintf("tenant %d invoices: %s", tenant.ID, err))
+		}
+		if err := s.reconcilePayouts(ctx, run, tenant); err != nil {
+			failures = append(failures, fmt.Sprintf("tenant %d payouts: %s", tenant.ID, err))
+		}
+	}
+
+	now := time.Now()
+	run.FinishedAt = &now
+	run.Status = model.ReconciliationCompleted
+	if len(failures) > 0 {
+		run.Status = model.ReconciliationPartial
+		run.Error = strings.Join(failures, "\n")
+	}
+
+	if err := s.reconciliationRepository.SaveRun(run); err != nil {
+		s.logger.Error("reconciliationRepository.SaveRun", zap.Uint("run_id", run.ID), zap.Error(err))
+	}
+
+	s.logger.Info("Reconciliation finished", zap.Uint("run_id", run.ID), zap.String("status", run.Status),
+		zap.Int("checked", run.Checked), zap.Int("mismatches", run.Mismatches), zap.Int("healed", run.Healed))
+}
+
+// StartScheduler runs reconciliation every RECONCILIATION_INTERVAL over the
+// preceding RECONCILIATION_WINDOW (24h by default) until ctx is done. It does
+// nothing when no interval is configured.
+func (s *reconciliationService) StartScheduler(ctx context.Context) {
+	interval, err := time.ParseDuration(os.Getenv("RECONCILIATION_INTERVAL"))
+	if err != nil || interval <= 0 {
+		s.logger.Info("Reconciliation scheduler disabled, RECONCILIATION_INTERVAL is not set")
+		return
+	}
+
+	window, err := time.ParseDuration(os.Getenv("RECONCILIATION_WINDOW"))
+	if err != nil || window <= 0 {
+		window = 24 * time.Hour
+	}
+
+	selfHeal, _ := strconv.ParseBool(os.Getenv("RECONCILIATION_SELF_HEAL"))
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				to := time.Now().Add(-reconciliationLag)
+				run, err := s.Begin(to.Add(-window), to, selfHeal)
+				if err != nil {
+					s.logger.Warn("Skipping scheduled reconciliation", zap.Error(err))
+					continue
+				}
+				s.Execute(ctx, run)
+			}
+		}
+	}()
+}
+
+// holdLock keeps extending the run's lock until the returned func is called,
+// which releases it.
+func (s *reconciliationService) holdLock(token string) func() {
+	done := make(chan struct{})
+
+	go func() {
+		ticker := time.NewTicker(reconciliationLockTTL / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				extended, err := s.redisLib.Run(context.Background(), extendLockScript, []string{reconciliationLockKey},
+					token, reconciliationLockTTL.Milliseconds())
+				if err != nil || extended == int64(0) {
+					s.logger.Error("Failed to extend reconciliation lock", zap.Any("extended", extended), zap.Error(err))
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		s.releaseLock(token)
+	}
+}
+
+func (s *reconciliationService) releaseLock(token string) {
+	if _, err := s.redisLib.Run(context.Background(), releaseLockScript, []string{reconciliationLockKey}, token); err != nil {
+		s.logger.Error("Failed to release reconciliation lock", zap.Error(err))
+	}
+}
+
+func lockToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate lock token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func (s *reconciliationService) GetRuns(limit int) ([]model.ReconciliationRun, error) {
+	return s.reconciliationRepository.FindRuns(limit)
+}
+
+func (s *reconciliationService) GetRun(id uint) (*model.ReconciliationRun, []model.ReconciliationMismatch, error) {
+	run, err := s.reconciliationRepository.FindRun(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mismatches, err := s.reconciliationRepository.FindMismatches(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return run, mismatches, nil
+}
+
+func (s *reconciliationService) reconcileInvoices(ctx context.Context, run *model.ReconciliationRun, tenant model.Tenant) error {
+	tenantID := strconv.FormatUint(uint64(tenant.ID), 10)
+	query := url.Values{}
+	query.Set("created_after", run.WindowStart.Format(time.RFC3339))
+	query.Set("created_before", run.WindowEnd.Format(time.RFC3339))
+	query.Set("limit", strconv.Itoa(reconciliationPageSize))
+
+	for page := 0; page < reconciliationMaxPages; page++ {
+		var invoices []json.RawMessage
+		if err := s.fetch(ctx, "/v2/invoices", query, tenant.AccountID, &invoices); err != nil {
+			return err
+		}
+
+		for _, raw := range invoices {
+			var remote dto.XenditInvoice
+			if err := json.Unmarshal(raw, &remote); err != nil {
+				return fmt.Errorf("invalid invoice in list: %w", err)
+			}
+			// Sub-accounts may be shared, so only the tenant's own references count.
+			if !strings.HasPrefix(remote.ExternalID, tenantID+":") {
+				continue
+			}
+
+			if err := s.checkInvoice(run, tenant.ID, tenantID, remote, raw); err != nil {
+				return err
+			}
+		}
+
+		if len(invoices) < reconciliationPageSize {
+			return nil
+		}
+
+		var last dto.XenditInvoice
+		json.Unmarshal(invoices[len(invoices)-1], &last)
+		query.Set("last_invoice_id", last.ID)
+	}
+
+	return errReconciliationTruncated
+}
+
+func (s *reconciliationService) checkInvoice(run *model.ReconciliationRun, tid uint, tenantID string, remote dto.XenditInvoice, raw []byte) error {
+	run.Checked++
+
+	local, err := s.invoiceRepository.Find(tid, remote.ID)
+	if err != nil {
+		return err
+	}
+
+	mismatch := &model.ReconciliationMismatch{
+		RunID:        run.ID,
+		TenantID:     tid,
+		Resource:     "INVOICE",
+		ResourceID:   remote.ID,
+		Reference:    helper.StripTenantReference(remote.ExternalID),
+		RemoteStatus: remote.Status,
+		RemoteAmount: remote.Amount,
+	}
+
+	heal := func() error {
+		if err := s.invoiceService.SyncInvoice(tenantID, remote); err != nil {
+			return err
+		}
+		// A healed PAID invoice still owes the platform fee and ledger entry its
+		// callback would have recorded.
+		if err := s.feeService.HandleInvoiceCallback(tenantID, raw); err != nil {
+			return err
+		}
+		return s.ledgerService.HandleInvoiceCallback(tenantID, raw)
+	}
+
+	switch {
+	case local == nil:
+		mismatch.Type = model.MismatchMissingLocally
+		return s.report(run, mismatch, heal)
+	case local.Amount != remote.Amount:
+		mismatch.Type = model.MismatchAmountDrift
+		mismatch.LocalStatus = local.Status
+		mismatch.LocalAmount = local.Amount
+		return s.report(run, mismatch, nil)
+	case local.Status != remote.Status:
+		mismatch.Type = model.MismatchStatusDrift
+		if local.Status == "PENDING" {
+			mismatch.Type = model.MismatchMissingWebhook
+		}
+		mismatch.LocalStatus = local.Status
+		mismatch.LocalAmount = local.Amount
+		if !healsForward(invoiceStatusOrder, local.Status, remote.Status) {
+			heal = nil
+		}
+		return s.report(run, mismatch, heal)
+	}
+
+	return nil
+}
+
+// reconcilePayouts finds the window's payouts through the transactions API,
+// which can filter by date. A payout whose transaction agrees with the local
+// record is counted as checked, and only the others are read from the payouts
+// API for their full state.
+func (s *reconciliationService) reconcilePayouts(ctx context.Context, run *model.ReconciliationRun, tenant model.Tenant) error {
+	tenantID := strconv.FormatUint(uint64(tenant.ID), 10)
+	query := url.Values{}
+	query.Set("types", "DISBURSEMENT")
+	query.Set("created[gte]", run.WindowStart.Format(time.RFC3339))
+	query.Set("created[lte]", run.WindowEnd.Format(time.RFC3339))
+	query.Set("limit", "50")
+
+	for page := 0; page < reconciliationMaxPages; page++ {
+		var list dto.XenditTransactionList
+		if err := s.fetch(ctx, "/transactions", query, tenant.AccountID, &list); err != nil {
+			return err
+		}
+
+		for _, transaction := range list.Data {
+			if transaction.ProductID == "" || !strings.HasPrefix(transaction.ReferenceID, tenantID+":") {
+				continue
+			}
+
+			local, err := s.payoutRepository.FindIfExists(tenant.ID, transaction.ProductID)
+			if err != nil {
+				return err
+			}
+			if local != nil && local.Amount == math.Abs(transaction.Amount) && transactionMatchesPayout(transaction.Status, local.Status) {
+				run.Checked++
+				continue
+			}
+
+			var remote dto.XenditPayout
+			if err := s.fetch(ctx, "/v2/payouts/"+url.PathEscape(transaction.ProductID), nil, tenant.AccountID, &remote); err != nil {
+				return err
+			}
+
+			if err := s.checkPayout(run, tenant.ID, tenantID, remote); err != nil {
+				return err
+			}
+		}
+
+		if !list.HasMore || len(list.Data) == 0 {
+			return nil
+		}
+		query.Set("after_id", list.Data[len(list.Data)-1].ID)
+	}
+
+	return errReconciliationTruncated
+}
+
+// transactionMatchesPayout reports whether a disbursement transaction status
+// agrees with a payout status. Transactions only have a few coarse statuses,
+// so PENDING covers every payout still in flight.
+func transactionMatchesPayout(transactionStatus, payoutStatus string) bool {
+	switch transactionStatus {
+	case "SUCCESS":
+		return payoutStatus == "SUCCEEDED"
+	case "FAILED":
+		return payoutStatus == "FAILED"
+	case "VOIDED":
+		return payoutStatus == "CANCELLED"
+	case "REVERSED":
+		return payoutStatus == "REVERSED"
+	case "PENDING":
+		return payoutStatus == "ACCEPTED" || payoutStatus == "REQUESTED" || payoutStatus == "LOCKED"
+	}
+	return false
+}
+
+func (s *reconciliationService) checkPayout(run *model.ReconciliationRun, tid uint, tenantID string, remote dto.XenditPayout) error {
+	run.Checked++
+
+	local, err := s.payoutRepository.FindIfExists(tid, remote.ID)
+	if err != nil {
+		return err
+	}
+
+	mismatch := &model.ReconciliationMismatch{
+		RunID:        run.ID,
+		TenantID:     tid,
+		Resource:     "PAYOUT",
+		ResourceID:   remote.ID,
+		Reference:    helper.StripTenantReference(remote.ReferenceID),
+		RemoteStatus: remote.Status,
+		RemoteAmount: remote.Amount,
+	}
+
+	heal := func() error {
+		if err := s.payoutService.SyncPayout(tenantID, remote); err != nil {
+			return err
+		}
+		return s.ledgerService.PostPayout(tenantID, remote)
+	}
+
+	switch {
+	case local == nil:
+		mismatch.Type = model.MismatchMissingLocally
+		return s.report(run, mismatch, heal)
+	case local.Amount != remote.Amount:
+		mismatch.Type = model.MismatchAmountDrift
+		mismatch.LocalStatus = local.Status
+		mismatch.LocalAmount = local.Amount
+		return s.report(run, mismatch, nil)
+	case local.Status != remote.Status:
+		mismatch.Type = model.MismatchStatusDrift
+		if local.Status == "ACCEPTED" || local.Status == "REQUESTED" {
+			mismatch.Type = model.MismatchMissingWebhook
+		}
+		mismatch.LocalStatus = local.Status
+		mismatch.LocalAmount = local.Amount
+		if !healsForward(payoutStatusOrder, local.Status, remote.Status) {
+			heal = nil
+		}
+		return s.report(run, mismatch, heal)
+	}
+
+	return nil
+}
+
+// invoiceStatusOrder and payoutStatusOrder rank statuses by how far along
+// their lifecycle they are. Statuses sharing a rank are alternative outcomes.
+var (
+	invoiceStatusOrder = map[string]int{"PENDING": 0, "PAID": 1, "EXPIRED": 1, "SETTLED": 2}
+	payoutStatusOrder  = map[string]int{"ACCEPTED": 0, "REQUESTED": 0, "LOCKED": 1, "SUCCEEDED": 2, "FAILED": 2, "CANCELLED": 2, "REVERSED": 3}
+)
+
+// healsForward reports whether applying the remote status moves the local
+// record further along its lifecycle. The remote state may be older than a
+// webhook that landed during the run, so a heal must never move it back.
+func healsForward(order map[string]int, localStatus, remoteStatus string) bool {
+	local, ok := order[localStatus]
+	if !ok {
+		return false
+	}
+	remote, ok := order[remoteStatus]
+	return ok && remote > local
+}
+
+// report stores the mismatch, first applying Xendit's state locally when the
+// run self-heals. Amount drift is never healed since it needs a person to look.
+func (s *reconciliationService) report(run *model.ReconciliationRun, mismatch *model.ReconciliationMismatch, heal func() error) error {
+	if run.SelfHeal && heal != nil {
+		if err := heal(); err != nil {
+			s.logger.Error("Failed to heal reconciliation mismatch", zap.Uint("run_id", run.ID),
+				zap.String("resource_id", mismatch.ResourceID), zap.Error(err))
+		} else {
+			mismatch.Healed = true
+			run.Healed++
+		}
+	}
+
+	run.Mismatches++
+	return s.reconciliationRepository.CreateMismatch(mismatch)
+}
+
+func (s *reconciliationService) fetch(ctx context.Context, endpoint string, query url.Values, accountID string, out interface{}) error {
+	resp, err := s.xenditService.GetRequest(ctx, endpoint, query, accountID)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("xendit responded to %s with status %d", endpoint, resp.StatusCode)
+	}
+
+	if err := json.Unmarshal(resp.Body, out); err != nil {
+		return fmt.Errorf("invalid response from %s: %w", endpoint, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package service
+
+import "testing"
+
+func TestHealsForward(t *testing.T) {
+	tests := []struct {
+		name   string
+		order  map[string]int
+		local  string
+		remote string
+		want   bool
+	}{
+		{"invoice paid", invoiceStatusOrder, "PENDING", "PAID", true},
+		{"invoice settled", invoiceStatusOrder, "PAID", "SETTLED", true},
+		{"invoice paid by webhook", invoiceStatusOrder, "PAID", "PENDING", false},
+		{"invoice paid then expired", invoiceStatusOrder, "PAID", "EXPIRED", false},
+		{"invoice unknown remote", invoiceStatusOrder, "PENDING", "VOIDED", false},
+		{"payout succeeded", payoutStatusOrder, "ACCEPTED", "SUCCEEDED", true},
+		{"payout reversed", payoutStatusOrder, "SUCCEEDED", "REVERSED", true},
+		{"payout succeeded by webhook", payoutStatusOrder, "SUCCEEDED", "ACCEPTED", false},
+		{"payout failed then succeeded", payoutStatusOrder, "FAILED", "SUCCEEDED", false},
+		{"payout unknown local", payoutStatusOrder, "DRAFT", "SUCCEEDED", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := healsForward(tt.order, tt.local, tt.remote); got != tt.want {
+				t.Errorf("healsForward(%s, %s) = %v, want %v", tt.local, tt.remote, got, tt.want)
+			}
+		})
+	}
+}
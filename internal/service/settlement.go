@@ -0,0 +1,276 @@
+package service
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"payment-broker/internal/helper"
+	"payment-broker/internal/model/dto"
+	"payment-broker/internal/repository"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// settlementColumns maps each value the statement needs to the header names
+// Xendit uses for it across its transaction and settlement reports.
+var settlementColumns = map[string][]string{
+	"reference": {"reference", "reference id", "reference_id", "external id", "external_id"},
+	"type":      {"type", "transaction type"},
+	"currency":  {"currency"},
+	"amount":    {"amount", "gross amount", "transaction amount"},
+	"fee":       {"fee", "xendit fee", "fee amount", "transaction fee"},
+	"vat":       {"vat", "fee vat", "vat amount"},
+	"split":     {"split amount", "split", "platform fee"},
+}
+
+// Kinds of settlement report lines.
+const (
+	settlementIncome = "INCOME"
+	settlementPayout = "PAYOUT"
+	settlementRefund = "REFUND"
+	settlementSplit  = "SPLIT"
+)
+
+type SettlementService interface {
+	Import(r io.Reader) (*dto.SettlementStatement, error)
+	WriteStatementCSV(statement *dto.SettlementStatement, w io.Writer) error
+}
+
+type settlementService struct {
+	tenantRepository  repository.TenantRepository
+	invoiceRepository repository.InvoiceRepository
+	payoutRepository  repository.PayoutRepository
+}
+
+func NewSettlementService(tenantRepository repository.TenantRepository, invoiceRepository repository.InvoiceRepository,
+	payoutRepository repository.PayoutRepository) SettlementService {
+	return &settlementService{
+		tenantRepository:  tenantRepository,
+		invoiceRepository: invoiceRepository,
+		payoutRepository:  payoutRepository,
+	}
+}
+
+// Import reads a Xendit report CSV and totals each tenant's lines per currency.
+// Lines are matched to the tenant through the prefixed reference and must belong
+// to an invoice or payout the broker stored. Each line is classified by its
+// type, or by the broker record its reference belongs to when the type says
+// nothing: split lines count towards the split, payout and refund lines are
+// outflows taken off the net, and the rest is gross income.
+func (s *settlementService) Import(r io.Reader) (*dto.SettlementStatement, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report header: %w", err)
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		for key, aliases := range settlementColumns {
+			for _, alias := range aliases {
+				if _, seen := columns[key]; !seen && name == alias {
+					columns[key] = i
+				}
+			}
+		}
+	}
+
+	for _, key := range []string{"reference", "currency", "amount"} {
+		if _, ok := columns[key]; !ok {
+			return nil, fmt.Errorf("report has no %s column", key)
+		}
+	}
+
+	statement := &dto.SettlementStatement{
+		Tenants:   []dto.TenantSettlement{},
+		Unmatched: []dto.UnmatchedSettlementLine{},
+	}
+	totals := map[uint]map[string]*dto.SettlementTotal{}
+	tenantNames := map[uint]string{}
+
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		statement.Lines++
+
+		field := func(key string) string {
+			i, ok := columns[key]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		reference := field("reference")
+		unmatched := func(reason string) {
+			statement.Unmatched = append(statement.Unmatched, dto.UnmatchedSettlementLine{Line: line, Reference: reference, Reason: reason})
+		}
+
+		prefix, ref, ok := strings.Cut(reference, ":")
+		if !ok {
+			unmatched("reference has no tenant prefix")
+			continue
+		}
+
+		tenantID, err := helper.ParseTenantID(prefix)
+		if err != nil {
+			unmatched("reference has no tenant prefix")
+			continue
+		}
+
+		if _, known := tenantNames[tenantID]; !known {
+			tenant, err := s.tenantRepository.FindIfExists(tenantID)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			if tenant == nil {
+				unmatched("unknown tenant")
+				continue
+			}
+			tenantNames[tenantID] = tenant.Name
+		}
+
+		kind, err := s.findTransaction(tenantID, ref)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if kind == "" {
+			unmatched("no broker invoice or payout with this reference")
+			continue
+		}
+
+		amount, err := parseReportAmount(field("amount"))
+		if err != nil {
+			unmatched("invalid amount")
+			continue
+		}
+		fee, _ := parseReportAmount(field("fee"))
+		vat, _ := parseReportAmount(field("vat"))
+		split, _ := parseReportAmount(field("split"))
+
+		currency := strings.ToUpper(field("currency"))
+		if totals[tenantID] == nil {
+			totals[tenantID] = map[string]*dto.SettlementTotal{}
+		}
+		total := totals[tenantID][currency]
+		if total == nil {
+			total = &dto.SettlementTotal{Currency: currency}
+			totals[tenantID][currency] = total
+		}
+
+		// Reports differ on whether outflows are negative, so the type alone
+		// decides the sign.
+		amount = math.Abs(amount)
+
+		var gross, payout, refund float64
+		switch classifySettlementLine(field("type"), kind) {
+		case settlementSplit:
+			split += amount
+		case settlementPayout:
+			payout = amount
+			total.Count++
+		case settlementRefund:
+			refund = amount
+			total.Count++
+		default:
+			gross = amount
+			total.Count++
+		}
+
+		net := gross - payout - refund - fee - vat - split
+
+		total.Gross = helper.RoundAmount(total.Gross+gross, currency)
+		total.Payouts = helper.RoundAmount(total.Payouts+payout, currency)
+		total.Refunds = helper.RoundAmount(total.Refunds+refund, currency)
+		total.Fees = helper.RoundAmount(total.Fees+fee+vat, currency)
+		total.Split = helper.RoundAmount(total.Split+split, currency)
+		total.Net = helper.RoundAmount(total.Net+net, currency)
+		statement.Matched++
+	}
+
+	tenantIDs := make([]uint, 0, len(totals))
+	for id := range totals {
+		tenantIDs = append(tenantIDs, id)
+	}
+	sort.Slice(tenantIDs, func(i, j int) bool { return tenantIDs[i] < tenantIDs[j] })
+
+	for _, id := range tenantIDs {
+		settlement := dto.TenantSettlement{TenantID: id, TenantName: tenantNames[id]}
+		for _, total := range totals[id] {
+			settlement.Totals = append(settlement.Totals, *total)
+		}
+		sort.Slice(settlement.Totals, func(i, j int) bool { return settlement.Totals[i].Currency < settlement.Totals[j].Currency })
+		statement.Tenants = append(statement.Tenants, settlement)
+	}
+
+	return statement, nil
+}
+
+func (s *settlementService) WriteStatementCSV(statement *dto.SettlementStatement, w io.Writer) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"tenant_id", "tenant_name", "currency", "transactions", "gross", "payouts", "refunds", "fees", "split", "net"})
+
+	for _, tenant := range statement.Tenants {
+		for _, total := range tenant.Totals {
+			writer.Write([]string{strconv.FormatUint(uint64(tenant.TenantID), 10), tenant.TenantName, total.Currency,
+				strconv.Itoa(total.Count), formatAmount(total.Gross), formatAmount(total.Payouts), formatAmount(total.Refunds),
+				formatAmount(total.Fees), formatAmount(total.Split), formatAmount(total.Net)})
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// findTransaction returns which kind of broker record has the reference, or an
+// empty string when none has.
+func (s *settlementService) findTransaction(tenantID uint, reference string) (string, error) {
+	found, err := s.invoiceRepository.HasReference(tenantID, reference)
+	if err != nil {
+		return "", err
+	}
+	if found {
+		return settlementIncome, nil
+	}
+
+	found, err = s.payoutRepository.HasReference(tenantID, reference)
+	if err != nil || !found {
+		return "", err
+	}
+	return settlementPayout, nil
+}
+
+// classifySettlementLine reads the report's transaction type, e.g. PAYMENT,
+// DISBURSEMENT or REFUND, and falls back to the kind of broker record the
+// reference belongs to.
+func classifySettlementLine(lineType string, kind string) string {
+	lineType = strings.ToUpper(lineType)
+	switch {
+	case strings.Contains(lineType, "SPLIT"):
+		return settlementSplit
+	case strings.Contains(lineType, "REFUND"):
+		return settlementRefund
+	case strings.Contains(lineType, "DISBURSEMENT"), strings.Contains(lineType, "PAYOUT"), strings.Contains(lineType, "WITHDRAW"):
+		return settlementPayout
+	case strings.Contains(lineType, "PAYMENT"), strings.Contains(lineType, "INVOICE"):
+		return settlementIncome
+	}
+	return kind
+}
+
+// parseReportAmount accepts amounts with thousands separators, e.g. "1,250,000.00".
+func parseReportAmount(value string) (float64, error) {
+	return strconv.ParseFloat(strings.ReplaceAll(value, ",", ""), 64)
+}
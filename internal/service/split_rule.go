@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"payment-broker/internal/helper"
+	model "payment-broker/internal/model/db"
+	"payment-broker/internal/model/dto"
+	"payment-broker/internal/repository"
+
+	"go.uber.org/zap"
+)
+
+// SplitRuleEndpoints lists the Xendit endpoints that accept the with-split-rule
+// header. Requests to any other endpoint are sent without a split.
+var SplitRuleEndpoints = []string{"/v2/invoices", "/recurring/plans"}
+
+type SplitRuleService interface {
+	CreateSplitRule(ctx context.Context, body dto.XenditCreateSplitRule) (*dto.XenditSplitRule, error)
+	AttachSplitRule(tenantID uint, body dto.AttachSplitRule) (*model.SplitRule, error)
+	DetachSplitRule(tenantID uint, endpoint string) error
+	GetTenantSplitRules(tenantID uint) ([]model.SplitRule, error)
+	ResolveSplitRule(tenantID string, endpoint string) string
+}
+
+type splitRuleService struct {
+	logger              *zap.Logger
+	defaultSplitRuleID  string
+	xenditService       XenditService
+	tenantRepository    repository.TenantRepository
+	splitRuleRepository repository.SplitRuleRepository
+}
+
+func NewSplitRuleService(logger *zap.Logger, xenditService XenditService, tenantRepository repository.TenantRepository,
+	splitRuleRepository repository.SplitRuleRepository) SplitRuleService {
+	return &splitRuleService{
+		logger:              logger,
+		defaultSplitRuleID:  os.Getenv("XENDIT_SPLIT_RULE_ID"),
+		xenditService:       xenditService,
+		tenantRepository:    tenantRepository,
+		splitRuleRepository: splitRuleRepository,
+	}
+}
+
+func (s *splitRuleService) CreateSplitRule(ctx context.Context, body dto.XenditCreateSplitRule) (*dto.XenditSplitRule, error) {
+	if body.Name == "" || len(body.Routes) == 0 {
+		return nil, fmt.Errorf("split rule needs a name and at least one route")
+	}
+
+	for _, route := range body.Routes {
+		if (route.FlatAmount == nil) == (route.PercentAmount == nil) {
+			return nil, fmt.Errorf("each route needs exactly one of flat_amount or percent_amount")
+		}
+		if route.Currency == "" || route.DestinationAccountID == "" || route.ReferenceID == "" {
+			return nil, fmt.Errorf("each route needs currency, destination_account_id and reference_id")
+		}
+	}
+
+	return s.xenditService.CreateSplitRule(ctx, body)
+}
+
+func (s *splitRuleService) AttachSplitRule(tenantID uint, body dto.AttachSplitRule) (*model.SplitRule, error) {
+	if body.SplitRuleID == "" {
+		return nil, fmt.Errorf("split_rule_id is required")
+	}
+
+	if !isSplitRuleEndpoint(body.Endpoint) {
+		return nil, fmt.Errorf("endpoint %q does not accept split rules", body.Endpoint)
+	}
+
+	if _, err := s.tenantRepository.FindByID(tenantID); err != nil {
+		return nil, fmt.Errorf("tenant not found: %w", err)
+	}
+
+	rule := &model.SplitRule{
+		TenantID:          tenantID,
+		Endpoint:          body.Endpoint,
+		XenditSplitRuleID: body.SplitRuleID,
+	}
+
+	if err := s.splitRuleRepository.Upsert(rule); err != nil {
+		s.logger.Error("splitRuleRepository.Upsert", zap.Uint("tenant_id", tenantID), zap.Error(err))
+		return nil, fmt.Errorf("failed to attach split rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+func (s *splitRuleService) DetachSplitRule(tenantID uint, endpoint string) error {
+	return s.splitRuleRepository.Delete(tenantID, endpoint)
+}
+
+func (s *splitRuleService) GetTenantSplitRules(tenantID uint) ([]model.SplitRule, error) {
+	return s.splitRuleRepository.FindByTenant(tenantID)
+}
+
+// ResolveSplitRule picks the split rule for a tenant request: the endpoint rule,
+// then the tenant default, then XENDIT_SPLIT_RULE_ID. It returns an empty string
+// for endpoints that don't take a split so the header is omitted.
+func (s *splitRuleService) ResolveSplitRule(tenantID string, endpoint string) string {
+	if !isSplitRuleEndpoint(endpoint) || endpoint == "" {
+		return ""
+	}
+
+	id, err := helper.ParseTenantID(tenantID)
+	if err != nil {
+		s.logger.Error("helper.ParseTenantID", zap.String("tenant_id", tenantID), zap.Error(err))
+		return s.defaultSplitRuleID
+	}
+
+	for _, scope := range []string{endpoint, ""} {
+		rule, err := s.splitRuleRepository.Find(id, scope)
+		if err != nil {
+			return s.defaultSplitRuleID
+		}
+		if rule != nil {
+			return rule.XenditSplitRuleID
+		}
+	}
+
+	return s.defaultSplitRuleID
+}
+
+func isSplitRuleEndpoint(endpoint string) bool {
+	if endpoint == "" {
+		return true
+	}
+
+	for _, e := range SplitRuleEndpoints {
+		if e == endpoint {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"payment-broker/internal/helper"
+	model "payment-broker/internal/model/db"
+	"payment-broker/internal/model/dto"
+	"payment-broker/internal/repository"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+type SubscriptionService interface {
+	RecordPlan(tenantID string, resp *dto.XenditResponse)
+	HandleWebhook(tenantID string, event string, data json.RawMessage) error
+	GetPlans(tenantID string, status string) ([]model.SubscriptionPlan, error)
+	GetPlan(tenantID string, planID string) (*model.SubscriptionPlan, error)
+	GetCycles(tenantID string, planID string) ([]model.SubscriptionCycle, error)
+}
+
+type subscriptionService struct {
+	logger                 *zap.Logger
+	subscriptionRepository repository.SubscriptionRepository
+}
+
+func NewSubscriptionService(logger *zap.Logger, subscriptionRepository repository.SubscriptionRepository) SubscriptionService {
+	return &subscriptionService{
+		logger:                 logger,
+		subscriptionRepository: subscriptionRepository,
+	}
+}
+
+// RecordPlan stores the plan returned by a successful create, update or
+// deactivate call. Failures are only logged since the tenant already got
+// Xendit's response and the next recurring.plan.* webhook will catch up.
+func (s *subscriptionService) RecordPlan(tenantID string, resp *dto.XenditResponse) {
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return
+	}
+
+	var plan dto.XenditRecurringPlan
+	if err := json.Unmarshal(resp.Body, &plan); err != nil || plan.ID == "" {
+		s.logger.Error("failed to parse recurring plan response", zap.String("tenant_id", tenantID), zap.Error(err))
+		return
+	}
+
+	if err := s.savePlan(tenantID, plan); err != nil {
+		s.logger.Error("subscriptionService.savePlan", zap.String("tenant_id", tenantID), zap.String("plan_id", plan.ID), zap.Error(err))
+	}
+}
+
+func (s *subscriptionService) HandleWebhook(tenantID string, event string, data json.RawMessage) error {
+	switch {
+	case strings.HasPrefix(event, "recurring.plan."):
+		var plan dto.XenditRecurringPlan
+		err := json.Unmarshal(data, &plan)
+		if err := payloadError(err, plan.ID); err != nil {
+			s.logger.Warn("Skipping malformed recurring plan webhook", zap.String("tenant_id", tenantID),
+				zap.String("event", event), zap.Error(err))
+			return nil
+		}
+		return s.savePlan(tenantID, plan)
+
+	case strings.HasPrefix(event, "recurring.cycle."):
+		var cycle dto.XenditRecurringCycle
+		err := json.Unmarshal(data, &cycle)
+		if err := payloadError(err, cycle.ID); err != nil {
+			s.logger.Warn("Skipping malformed recurring cycle webhook", zap.String("tenant_id", tenantID),
+				zap.String("event", event), zap.Error(err))
+			return nil
+		}
+		return s.saveCycle(tenantID, cycle)
+	}
+
+	return nil
+}
+
+func (s *subscriptionService) GetPlans(tenantID string, status string) ([]model.SubscriptionPlan, error) {
+	id, err := helper.ParseTenantID(tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return s.subscriptionRepository.FindPlans(id, status)
+}
+
+func (s *subscriptionService) GetPlan(tenantID string, planID string) (*model.SubscriptionPlan, error) {
+	id, err := helper.ParseTenantID(tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return s.subscriptionRepository.FindPlan(id, planID)
+}
+
+func (s *subscriptionService) GetCycles(tenantID string, planID string) ([]model.SubscriptionCycle, error) {
+	id, err := helper.ParseTenantID(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.subscriptionRepository.FindPlan(id, planID); err != nil {
+		return nil, err
+	}
+
+	return s.subscriptionRepository.FindCycles(id, planID)
+}
+
+func (s *subscriptionService) savePlan(tenantID string, plan dto.XenditRecurringPlan) error {
+	id, err := helper.ParseTenantID(tenantID)
+	if err != nil {
+		return err
+	}
+
+	return s.subscriptionRepository.UpsertPlan(&model.SubscriptionPlan{
+		TenantID:    id,
+		PlanID:      plan.ID,
+		ReferenceID: helper.StripTenantReference(plan.ReferenceID),
+		CustomerID:  plan.CustomerID,
+		Currency:    plan.Currency,
+		Amount:      plan.Amount,
+		Status:      plan.Status,
+	})
+}
+
+func (s *subscriptionService) saveCycle(tenantID string, cycle dto.XenditRecurringCycle) error {
+	id, err := helper.ParseTenantID(tenantID)
+	if err != nil {
+		return err
+	}
+
+	return s.subscriptionRepository.UpsertCycle(&model.SubscriptionCycle{
+		TenantID:     id,
+		PlanID:       cycle.PlanID,
+		CycleID:      cycle.ID,
+		CycleNumber:  cycle.CycleNumber,
+		AttemptCount: cycle.AttemptCount,
+		Currency:     cycle.Currency,
+		Amount:       cycle.Amount,
+		Status:       cycle.Status,
+		FailureCode:  cycle.FailureCode,
+		ScheduledAt:  cycle.ScheduledTimestamp,
+	})
+}
@@ -20,6 +20,10 @@ import (
 type TenantService interface {
 	CheckAPIKey(ctx context.Context, APIKey string) (*dto.TenantCheckAPIKey, error)
 	CreateTenant(name, accountID, webhookURL string) (*model.Tenant, error)
+	OnboardTenant(ctx context.Context, name, email, accountType, webhookURL string) (*model.Tenant, error)
+	UpdateAccountStatus(accountID, status string) (bool, error)
+	GetCurrencies(tenantID uint) (*dto.TenantCurrencies, error)
+	SetCurrencies(tenantID uint, body dto.TenantCurrenciesRequest) (*dto.TenantCurrencies, error)
 	GetAllTenants() ([]model.Tenant, error)
 	GetTenantByID(id uint) (*model.Tenant, error)
 	DeleteTenant(id uint) error
@@ -30,15 +34,19 @@ type tenantService struct {
 	resty            *resty.Client
 	redisLib         lib.RedisLib
 	tenantRepository repository.TenantRepository
+	xenditService    XenditService
+	egress           *helper.EgressPolicy
 }
 
 func NewTenantService(logger *zap.Logger, resty *resty.Client, redisLib lib.RedisLib,
-	tenantRepository repository.TenantRepository) TenantService {
+	tenantRepository repository.TenantRepository, xenditService XenditService, egress *helper.EgressPolicy) TenantService {
 	return &tenantService{
 		logger:           logger,
 		resty:            resty,
 		redisLib:         redisLib,
 		tenantRepository: tenantRepository,
+		xenditService:    xenditService,
+		egress:           egress,
 	}
 }
 
@@ -55,6 +63,10 @@ func (s *tenantService) DeleteTenant(id uint) error {
 }
 
 func (s *tenantService) CreateTenant(name, accountID, webhookURL string) (*model.Tenant, error) {
+	if err := s.egress.CheckURL(context.Background(), webhookURL); err != nil {
+		return nil, fmt.Errorf("webhook URL %w", err)
+	}
+
 	tenant := &model.Tenant{
 		Name:       name,
 		AccountID:  accountID,
@@ -70,6 +82,92 @@ func (s *tenantService) CreateTenant(name, accountID, webhookURL string) (*model
 	return tenant, nil
 }
 
+// OnboardTenant creates the tenant's xenPlatform sub-account and stores the tenant
+// with the returned account ID in one step. The webhook URL is checked against
+// the egress policy before the account is created.
+func (s *tenantService) OnboardTenant(ctx context.Context, name, email, accountType, webhookURL string) (*model.Tenant, error) {
+	if err := s.egress.CheckURL(ctx, webhookURL); err != nil {
+		return nil, fmt.Errorf("webhook URL %w", err)
+	}
+
+	account, err := s.xenditService.CreateAccount(ctx, dto.XenditCreateAccount{
+		Email: email,
+		Type:  accountType,
+		PublicProfile: dto.XenditAccountPublicProfile{
+			BusinessName: name,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Xendit account: %w", err)
+	}
+
+	tenant := &model.Tenant{
+		Name:          name,
+		Email:         email,
+		AccountID:     account.ID,
+		AccountType:   account.Type,
+		AccountStatus: account.Status,
+		WebhookURL:    webhookURL,
+		APIKey:        helper.GenerateAPIKey(),
+	}
+
+	if err := s.tenantRepository.Create(tenant); err != nil {
+		s.logger.Error("tenantRepository.Create", zap.String("account_id", account.ID), zap.Error(err))
+		return nil, fmt.Errorf("account %s created but tenant was not saved: %w", account.ID, err)
+	}
+
+	return tenant, nil
+}
+
+func (s *tenantService) UpdateAccountStatus(accountID, status string) (bool, error) {
+	return s.tenantRepository.UpdateAccountStatus(accountID, status)
+}
+
+// GetCurrencies resolves the tenant's currency settings. An empty allowed list
+// means every supported currency, and without a default the first allowed
+// currency is used, or IDR when every currency is allowed.
+func (s *tenantService) GetCurrencies(tenantID uint) (*dto.TenantCurrencies, error) {
+	tenant, err := s.tenantRepository.FindByID(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	currencies := &dto.TenantCurrencies{
+		TenantID:          tenant.ID,
+		AllowedCurrencies: helper.SupportedCurrencies,
+		DefaultCurrency:   tenant.DefaultCurrency,
+	}
+	if tenant.AllowedCurrencies != "" {
+		currencies.AllowedCurrencies = strings.Split(tenant.AllowedCurrencies, ",")
+	}
+
+	if currencies.DefaultCurrency == "" {
+		currencies.DefaultCurrency = helper.DefaultCurrency
+		if tenant.AllowedCurrencies != "" {
+			currencies.DefaultCurrency = currencies.AllowedCurrencies[0]
+		}
+	}
+
+	return currencies, nil
+}
+
+func (s *tenantService) SetCurrencies(tenantID uint, body dto.TenantCurrenciesRequest) (*dto.TenantCurrencies, error) {
+	if errs := body.Validate(); len(errs) > 0 {
+		return nil, fmt.Errorf("%s %s", errs[0].Field, errs[0].Message)
+	}
+
+	if _, err := s.tenantRepository.FindByID(tenantID); err != nil {
+		return nil, fmt.Errorf("tenant not found: %w", err)
+	}
+
+	if err := s.tenantRepository.UpdateCurrencies(tenantID, strings.Join(body.AllowedCurrencies, ","), body.DefaultCurrency); err != nil {
+		s.logger.Error("tenantRepository.UpdateCurrencies", zap.Uint("tenant_id", tenantID), zap.Error(err))
+		return nil, fmt.Errorf("failed to save currencies: %w", err)
+	}
+
+	return s.GetCurrencies(tenantID)
+}
+
 func (s *tenantService) CheckAPIKey(ctx context.Context, APIKey string) (*dto.TenantCheckAPIKey, error) {
 	cached_accountID, err := s.redisLib.Get(ctx, APIKey)
 
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"payment-broker/internal/helper"
+	model "payment-broker/internal/model/db"
+	"payment-broker/internal/model/dto"
+	"payment-broker/internal/repository"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type UsageService interface {
+	Record(tenantID string, method string, endpoint string, status int)
+	GetMonthlyUsage(month string) ([]dto.UsageSummary, error)
+	Export(month string, format string, w io.Writer) error
+}
+
+type usageService struct {
+	logger          *zap.Logger
+	usageRepository repository.UsageRepository
+}
+
+func NewUsageService(logger *zap.Logger, usageRepository repository.UsageRepository) UsageService {
+	return &usageService{
+		logger:          logger,
+		usageRepository: usageRepository,
+	}
+}
+
+// Record meters one tenant call. Failures are only logged so metering never
+// fails the tenant's request.
+func (s *usageService) Record(tenantID string, method string, endpoint string, status int) {
+	tid, err := helper.ParseTenantID(tenantID)
+	if err != nil {
+		s.logger.Error("usageService.Record", zap.String("tenant_id", tenantID), zap.Error(err))
+		return
+	}
+
+	outcome := model.UsageSuccess
+	switch {
+	case status >= 500:
+		outcome = model.UsageServerError
+	case status >= 400:
+		outcome = model.UsageClientError
+	}
+
+	now := time.Now().UTC()
+	err = s.usageRepository.Increment(&model.UsageRecord{
+		TenantID:  tid,
+		Day:       now.Truncate(24 * time.Hour),
+		Method:    method,
+		Endpoint:  endpoint,
+		Outcome:   outcome,
+		Count:     1,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		s.logger.Error("usageRepository.Increment", zap.String("tenant_id", tenantID), zap.String("endpoint", endpoint), zap.Error(err))
+	}
+}
+
+// GetMonthlyUsage totals every tenant's usage in a UTC month (YYYY-MM).
+func (s *usageService) GetMonthlyUsage(month string) ([]dto.UsageSummary, error) {
+	from, err := time.Parse("2006-01", month)
+	if err != nil {
+		return nil, fmt.Errorf("month must be in YYYY-MM format")
+	}
+	return s.usageRepository.Summarize(from, from.AddDate(0, 1, 0))
+}
+
+// Export writes the month's usage as CSV or JSON for the billing system.
+func (s *usageService) Export(month string, format string, w io.Writer) error {
+	usage, err := s.GetMonthlyUsage(month)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case "json":
+		if usage == nil {
+			usage = []dto.UsageSummary{}
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(dto.UsageExport{Month: month, Usage: usage})
+
+	case "csv":
+		writer := csv.NewWriter(w)
+		writer.Write([]string{"month", "tenant_id", "tenant_name", "method", "endpoint", "outcome", "count"})
+		for _, u := range usage {
+			writer.Write([]string{month, strconv.FormatUint(uint64(u.TenantID), 10), u.TenantName, u.Method,
+				u.Endpoint, u.Outcome, strconv.FormatInt(u.Count, 10)})
+		}
+		writer.Flush()
+		return writer.Error()
+	}
+
+	return fmt.Errorf("unsupported export format %q", format)
+}
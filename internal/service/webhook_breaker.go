@@ -0,0 +1,404 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	model "payment-broker/internal/model/db"
+	"payment-broker/internal/model/dto"
+	"payment-broker/internal/repository"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultBreakerThreshold = 5
+	defaultBreakerCooldown  = time.Minute
+
+	orderedRetryDelay      = 10 * time.Second
+	orderedRetryMaxDelay   = 10 * time.Minute
+	defaultOrderedAttempts = 20
+)
+
+type WebhookBreakerService interface {
+	Check(tenantID uint, url string) (*model.WebhookBreaker, error)
+	Report(tenantID uint, url string, err error)
+	Retry(event dto.Event, endpoint model.WebhookEndpoint, apiKey string, cause error) error
+	Enqueue(breaker *model.WebhookBreaker, event dto.Event, endpoint model.WebhookEndpoint, apiKey string) error
+	Due() ([]model.WebhookBreaker, error)
+	Claim(breaker *model.WebhookBreaker) (bool, error)
+	Next(breaker *model.WebhookBreaker) (*model.QueuedWebhook, *dto.Event, error)
+	Delivered(breaker *model.WebhookBreaker, item *model.QueuedWebhook) error
+	Close(breaker *model.WebhookBreaker, probed bool) error
+	Reopen(breaker *model.WebhookBreaker, err error) error
+	GetBreakers(tenantID uint) ([]dto.WebhookBreaker, error)
+	EnqueueOrdered(event dto.Event, endpoint model.WebhookEndpoint, apiKey string) error
+	OrderedHeads() ([]model.QueuedWebhook, error)
+	ClaimOrdered(item *model.QueuedWebhook) (bool, error)
+	NextOrdered(item *model.QueuedWebhook) (*model.QueuedWebhook, error)
+	RetryOrdered(item *model.QueuedWebhook, err error) error
+	SkipOrdered(tenantID uint, id uint, admin string) error
+	ResumeOrdered(tenantID uint, id uint, admin string) error
+	Decode(item *model.QueuedWebhook) (*dto.Event, error)
+	Wake() <-chan struct{}
+}
+
+type webhookBreakerService struct {
+	logger                   *zap.Logger
+	threshold                int
+	cooldown                 time.Duration
+	maxAttempts              int
+	wake                     chan struct{}
+	webhookBreakerRepository repository.WebhookBreakerRepository
+}
+
+// NewWebhookBreakerService opens a breaker after WEBHOOK_BREAKER_THRESHOLD
+// consecutive failures (5 by default) and probes it again after
+// WEBHOOK_BREAKER_COOLDOWN (1m by default). Ordered deliveries are
+// dead-lettered after WEBHOOK_ORDERED_MAX_ATTEMPTS failed attempts (20 by
+// default).
+func NewWebhookBreakerService(logger *zap.Logger, webhookBreakerRepository repository.WebhookBreakerRepository) WebhookBreakerService {
+	threshold, err := strconv.Atoi(os.Getenv("WEBHOOK_BREAKER_THRESHOLD"))
+	if err != nil || threshold < 1 {
+		threshold = defaultBreakerThreshold
+	}
+
+	cooldown, err := time.ParseDuration(os.Getenv("WEBHOOK_BREAKER_COOLDOWN"))
+	if err != nil || cooldown <= 0 {
+		cooldown = defaultBreakerCooldown
+	}
+
+	maxAttempts, err := strconv.Atoi(os.Getenv("WEBHOOK_ORDERED_MAX_ATTEMPTS"))
+	if err != nil || maxAttempts < 1 {
+		maxAttempts = defaultOrderedAttempts
+	}
+
+	return &webhookBreakerService{
+		logger:                   logger,
+		threshold:                threshold,
+		cooldown:                 cooldown,
+		maxAttempts:              maxAttempts,
+		wake:                     make(chan struct{}, 1),
+		webhookBreakerRepository: webhookBreakerRepository,
+	}
+}
+
+// Check returns the URL's breaker when deliveries to it must be queued, and
+// nil when they can be attempted.
+func (s *webhookBreakerService) Check(tenantID uint, url string) (*model.WebhookBreaker, error) {
+	breaker, err := s.webhookBreakerRepository.FindIfExists(tenantID, url)
+	if err != nil || breaker == nil || breaker.State == model.BreakerClosed {
+		return nil, err
+	}
+	return breaker, nil
+}
+
+// Report counts the outcome of a live delivery. The breaker opens once the
+// failures reach the threshold. Outcomes of deliveries that raced with the
+// breaker leaving CLOSED are left to the worker.
+func (s *webhookBreakerService) Report(tenantID uint, url string, err error) {
+	if err == nil {
+		breaker, findErr := s.webhookBreakerRepository.FindIfExists(tenantID, url)
+		if findErr == nil && breaker != nil && breaker.Failures > 0 {
+			findErr = s.webhookBreakerRepository.ResetFailures(breaker.ID)
+		}
+		if findErr != nil {
+			s.logger.Error("webhookBreakerService.Report", zap.Uint("tenant_id", tenantID), zap.Error(findErr))
+		}
+		return
+	}
+
+	if _, err := s.fail(tenantID, url, err); err != nil {
+		s.logger.Error("webhookBreakerService.fail", zap.Uint("tenant_id", tenantID), zap.Error(err))
+	}
+}
+
+// Retry counts a failed live delivery like Report and queues it on the URL's
+// breaker. The worker sends it on its next run, and while the breaker is open
+// it waits there with the deliveries held back by it.
+func (s *webhookBreakerService) Retry(event dto.Event, endpoint model.WebhookEndpoint, apiKey string, cause error) error {
+	breaker, err := s.fail(event.TenantID, endpoint.URL, cause)
+	if err != nil {
+		return err
+	}
+	return s.enqueue(breaker.ID, event, endpoint, apiKey)
+}
+
+// fail counts a failure against the URL's breaker and opens it once the
+// failures reach the threshold.
+func (s *webhookBreakerService) fail(tenantID uint, url string, cause error) (*model.WebhookBreaker, error) {
+	breaker, err := s.webhookBreakerRepository.AddFailure(tenantID, url, cause.Error())
+	if err != nil {
+		return nil, err
+	}
+
+	if breaker.State == model.BreakerClosed && breaker.Failures >= s.threshold {
+		if err := s.open(breaker, model.BreakerClosed, map[string]interface{}{"opened_at": time.Now()}); err != nil {
+			s.logger.Error("webhookBreakerService.open", zap.Uint("breaker_id", breaker.ID), zap.Error(err))
+		}
+	}
+	return breaker, nil
+}
+
+func (s *webhookBreakerService) Enqueue(breaker *model.WebhookBreaker, event dto.Event, endpoint model.WebhookEndpoint, apiKey string) error {
+	return s.enqueue(breaker.ID, event, endpoint, apiKey)
+}
+
+// EnqueueOrdered queues the event behind the other deliveries of its resource
+// to the endpoint and wakes the worker to send it.
+func (s *webhookBreakerService) EnqueueOrdered(event dto.Event, endpoint model.WebhookEndpoint, apiKey string) error {
+	if err := s.enqueue(0, event, endpoint, apiKey); err != nil {
+		return err
+	}
+
+	s.signal()
+	return nil
+}
+
+func (s *webhookBreakerService) Due() ([]model.WebhookBreaker, error) {
+	return s.webhookBreakerRepository.FindDue(time.Now())
+}
+
+// Claim moves a due breaker to HALF_OPEN so this worker alone drains it, for
+// one cooldown that Delivered renews. If the worker dies, the breaker is due
+// again once the claim runs out. It reports false when another worker got
+// there first.
+func (s *webhookBreakerService) Claim(breaker *model.WebhookBreaker) (bool, error) {
+	claimed, err := s.webhookBreakerRepository.Claim(breaker, time.Now().Add(s.cooldown))
+	if claimed {
+		breaker.State = model.BreakerHalfOpen
+	}
+	return claimed, err
+}
+
+// Next returns the oldest queued delivery of the breaker with its event, or
+// nil when the queue is empty.
+func (s *webhookBreakerService) Next(breaker *model.WebhookBreaker) (*model.QueuedWebhook, *dto.Event, error) {
+	item, err := s.webhookBreakerRepository.FindNextQueued(breaker.ID)
+	if err != nil || item == nil {
+		return nil, nil, err
+	}
+
+	event, err := s.Decode(item)
+	if err != nil {
+		return nil, nil, err
+	}
+	return item, event, nil
+}
+
+func (s *webhookBreakerService) Decode(item *model.QueuedWebhook) (*dto.Event, error) {
+	var event dto.Event
+	if err := json.Unmarshal([]byte(item.Event), &event); err != nil {
+		return nil, fmt.Errorf("invalid queued event %s: %w", item.EventID, err)
+	}
+	return &event, nil
+}
+
+// Delivered removes a sent delivery from the queue and, for a breaker's
+// queue, renews the worker's claim on the breaker.
+func (s *webhookBreakerService) Delivered(breaker *model.WebhookBreaker, item *model.QueuedWebhook) error {
+	if err := s.webhookBreakerRepository.DeleteQueued(item.ID); err != nil {
+		return err
+	}
+	if breaker == nil {
+		return nil
+	}
+	return s.webhookBreakerRepository.Extend(breaker.ID, time.Now().Add(s.cooldown))
+}
+
+// Close ends a probe with the queue drained. When nothing was queued to probe
+// with, the breaker closes one failure short of the threshold, so the next
+// live delivery decides whether it opens again.
+func (s *webhookBreakerService) Close(breaker *model.WebhookBreaker, probed bool) error {
+	failures := 0
+	if !probed {
+		failures = s.threshold - 1
+	}
+
+	closed, err := s.webhookBreakerRepository.Transition(breaker.ID, model.BreakerHalfOpen, model.BreakerClosed, map[string]interface{}{
+		"failures":      failures,
+		"last_error":    "",
+		"opened_at":     nil,
+		"next_probe_at": nil,
+	})
+	if err != nil {
+		return err
+	}
+
+	if closed {
+		s.logger.Info("Webhook breaker closed", zap.Uint("tenant_id", breaker.TenantID), zap.String("url", breaker.URL))
+	}
+	return nil
+}
+
+func (s *webhookBreakerService) Reopen(breaker *model.WebhookBreaker, err error) error {
+	return s.open(breaker, model.BreakerHalfOpen, map[string]interface{}{
+		"failures":   breaker.Failures + 1,
+		"last_error": err.Error(),
+	})
+}
+
+// GetBreakers returns the tenant's breakers, each with the failed ordered
+// deliveries to its URL that hold back their resource.
+func (s *webhookBreakerService) GetBreakers(tenantID uint) ([]dto.WebhookBreaker, error) {
+	breakers, err := s.webhookBreakerRepository.FindByTenant(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.webhookBreakerRepository.FindStuckOrdered(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	stuck := map[string][]dto.StuckWebhook{}
+	for _, item := range items {
+		stuck[item.URL] = append(stuck[item.URL], dto.StuckWebhook{
+			ID:             item.ID,
+			ResourceID:     item.ResourceID,
+			EventID:        item.EventID,
+			Attempts:       item.Attempts,
+			LastError:      item.LastError,
+			NextAttemptAt:  item.NextAttemptAt,
+			DeadLetteredAt: item.DeadLetteredAt,
+			QueuedAt:       item.CreatedAt,
+		})
+	}
+
+	result := make([]dto.WebhookBreaker, len(breakers))
+	for i, breaker := range breakers {
+		queued, err := s.webhookBreakerRepository.CountQueued(breaker.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		result[i] = dto.WebhookBreaker{WebhookBreaker: breaker, Queued: queued, Stuck: stuck[breaker.URL]}
+		if result[i].Stuck == nil {
+			result[i].Stuck = []dto.StuckWebhook{}
+		}
+	}
+	return result, nil
+}
+
+// open moves the breaker from the given state to OPEN and schedules its next
+// probe after the cooldown.
+func (s *webhookBreakerService) open(breaker *model.WebhookBreaker, from string, updates map[string]interface{}) error {
+	updates["next_probe_at"] = time.Now().Add(s.cooldown)
+
+	opened, err := s.webhookBreakerRepository.Transition(breaker.ID, from, model.BreakerOpen, updates)
+	if err != nil {
+		return err
+	}
+
+	if opened {
+		s.logger.Warn("Webhook breaker opened", zap.Uint("tenant_id", breaker.TenantID), zap.String("url", breaker.URL),
+			zap.Int("failures", breaker.Failures), zap.Duration("next_probe_in", s.cooldown))
+	}
+	return nil
+}
+
+func (s *webhookBreakerService) OrderedHeads() ([]model.QueuedWebhook, error) {
+	return s.webhookBreakerRepository.FindOrderedHeads(time.Now())
+}
+
+// ClaimOrdered holds an ordered delivery for one cooldown so this worker
+// alone sends it. It reports false when another worker got there first.
+func (s *webhookBreakerService) ClaimOrdered(item *model.QueuedWebhook) (bool, error) {
+	until := time.Now().Add(s.cooldown)
+	claimed, err := s.webhookBreakerRepository.ClaimQueued(item, until)
+	if claimed {
+		item.NextAttemptAt = &until
+	}
+	return claimed, err
+}
+
+func (s *webhookBreakerService) NextOrdered(item *model.QueuedWebhook) (*model.QueuedWebhook, error) {
+	return s.webhookBreakerRepository.FindNextOrdered(item)
+}
+
+// RetryOrdered keeps a failed ordered delivery at the head of its resource
+// and backs off exponentially before it is tried again. Once it has used up
+// its attempts it is dead-lettered instead.
+func (s *webhookBreakerService) RetryOrdered(item *model.QueuedWebhook, err error) error {
+	attempts := item.Attempts + 1
+	if attempts >= s.maxAttempts {
+		s.logger.Error("Ordered webhook delivery dead-lettered", zap.Uint("tenant_id", item.TenantID), zap.String("url", item.URL),
+			zap.String("resource_id", item.ResourceID), zap.Uint("id", item.ID), zap.Int("attempts", attempts), zap.Error(err))
+		return s.webhookBreakerRepository.DeadLetterQueued(item.ID, attempts, err.Error())
+	}
+
+	delay := orderedRetryMaxDelay
+	if attempts < 10 {
+		delay = min(orderedRetryDelay<<(attempts-1), orderedRetryMaxDelay)
+	}
+
+	s.logger.Warn("Ordered webhook delivery failed", zap.Uint("tenant_id", item.TenantID), zap.String("url", item.URL),
+		zap.String("resource_id", item.ResourceID), zap.Int("attempts", attempts), zap.Duration("retry_in", delay), zap.Error(err))
+	return s.webhookBreakerRepository.RetryQueued(item.ID, attempts, err.Error(), time.Now().Add(delay))
+}
+
+// SkipOrdered drops a failed ordered delivery for good and wakes the worker
+// to send the next one of its resource.
+func (s *webhookBreakerService) SkipOrdered(tenantID uint, id uint, admin string) error {
+	skipped, err := s.webhookBreakerRepository.SkipOrdered(tenantID, id)
+	if err != nil {
+		return err
+	}
+	if !skipped {
+		return fmt.Errorf("no failed ordered delivery %d for this tenant", id)
+	}
+
+	s.logger.Warn("Ordered webhook delivery skipped", zap.Uint("tenant_id", tenantID), zap.Uint("id", id), zap.String("admin", admin))
+	s.signal()
+	return nil
+}
+
+// ResumeOrdered sends a failed ordered delivery again right away, with a
+// fresh set of attempts.
+func (s *webhookBreakerService) ResumeOrdered(tenantID uint, id uint, admin string) error {
+	resumed, err := s.webhookBreakerRepository.ResumeOrdered(tenantID, id)
+	if err != nil {
+		return err
+	}
+	if !resumed {
+		return fmt.Errorf("no failed ordered delivery %d for this tenant", id)
+	}
+
+	s.logger.Info("Ordered webhook delivery resumed", zap.Uint("tenant_id", tenantID), zap.Uint("id", id), zap.String("admin", admin))
+	s.signal()
+	return nil
+}
+
+// Wake signals when an ordered delivery was queued, skipped or resumed in
+// this process.
+func (s *webhookBreakerService) Wake() <-chan struct{} {
+	return s.wake
+}
+
+func (s *webhookBreakerService) signal() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *webhookBreakerService) enqueue(breakerID uint, event dto.Event, endpoint model.WebhookEndpoint, apiKey string) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event %s: %w", event.ID, err)
+	}
+
+	return s.webhookBreakerRepository.Enqueue(&model.QueuedWebhook{
+		BreakerID:  breakerID,
+		TenantID:   event.TenantID,
+		EndpointID: endpoint.ID,
+		URL:        endpoint.URL,
+		ResourceID: event.Subject,
+		Format:     endpoint.Format,
+		EventID:    event.ID,
+		Event:      string(payload),
+		APIKey:     apiKey,
+	})
+}
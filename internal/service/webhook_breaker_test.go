@@ -0,0 +1,69 @@
+package service
+
+import (
+	"errors"
+	model "payment-broker/internal/model/db"
+	"payment-broker/internal/repository"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type recordingBreakerRepository struct {
+	repository.WebhookBreakerRepository
+	retriedAt    *time.Time
+	deadLettered bool
+	attempts     int
+}
+
+func (r *recordingBreakerRepository) RetryQueued(id uint, attempts int, lastError string, at time.Time) error {
+	r.attempts, r.retriedAt = attempts, &at
+	return nil
+}
+
+func (r *recordingBreakerRepository) DeadLetterQueued(id uint, attempts int, lastError string) error {
+	r.attempts, r.deadLettered = attempts, true
+	return nil
+}
+
+func TestRetryOrderedDeadLettersAfterMaxAttempts(t *testing.T) {
+	tests := []struct {
+		name         string
+		attempts     int
+		deadLettered bool
+		delay        time.Duration
+	}{
+		{"first failure", 0, false, orderedRetryDelay},
+		{"backs off", 3, false, 8 * orderedRetryDelay},
+		{"capped delay", 12, false, orderedRetryMaxDelay},
+		{"last attempt", 19, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &recordingBreakerRepository{}
+			s := &webhookBreakerService{logger: zap.NewNop(), maxAttempts: 20, webhookBreakerRepository: repo}
+
+			start := time.Now()
+			if err := s.RetryOrdered(&model.QueuedWebhook{Attempts: tt.attempts}, errors.New("status 500")); err != nil {
+				t.Fatalf("RetryOrdered() error = %v", err)
+			}
+
+			if repo.attempts != tt.attempts+1 {
+				t.Errorf("attempts = %d, want %d", repo.attempts, tt.attempts+1)
+			}
+			if repo.deadLettered != tt.deadLettered {
+				t.Errorf("dead-lettered = %v, want %v", repo.deadLettered, tt.deadLettered)
+			}
+			if !tt.deadLettered {
+				if repo.retriedAt == nil {
+					t.Fatal("RetryOrdered() didn't schedule a retry")
+				}
+				if delay := repo.retriedAt.Sub(start); delay < tt.delay || delay > tt.delay+time.Second {
+					t.Errorf("retry in %v, want %v", delay, tt.delay)
+				}
+			}
+		})
+	}
+}
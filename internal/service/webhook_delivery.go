@@ -0,0 +1,68 @@
+package service
+
+import (
+	"payment-broker/internal/helper"
+	model "payment-broker/internal/model/db"
+	"payment-broker/internal/model/dto"
+	"payment-broker/internal/repository"
+
+	"go.uber.org/zap"
+)
+
+// webhookResponseExcerpt is how much of an endpoint's response is kept.
+const webhookResponseExcerpt = 2048
+
+type WebhookDeliveryService interface {
+	Record(delivery *model.WebhookDelivery)
+	GetDeliveries(tenantID uint, filter dto.WebhookDeliveryFilter) ([]model.WebhookDelivery, error)
+	GetTenantDeliveries(tenantID string, filter dto.WebhookDeliveryFilter) ([]model.WebhookDelivery, error)
+}
+
+type webhookDeliveryService struct {
+	logger                    *zap.Logger
+	webhookDeliveryRepository repository.WebhookDeliveryRepository
+}
+
+func NewWebhookDeliveryService(logger *zap.Logger, webhookDeliveryRepository repository.WebhookDeliveryRepository) WebhookDeliveryService {
+	return &webhookDeliveryService{
+		logger:                    logger,
+		webhookDeliveryRepository: webhookDeliveryRepository,
+	}
+}
+
+// Record stores a delivery attempt. The API key header is masked and the
+// response body trimmed first. A failure to store it is only logged, so it
+// never changes the outcome of the delivery.
+func (s *webhookDeliveryService) Record(delivery *model.WebhookDelivery) {
+	if key, ok := delivery.RequestHeaders["X-Api-Key"]; ok {
+		delivery.RequestHeaders["X-Api-Key"] = maskSecret(key)
+	}
+	if len(delivery.ResponseBody) > webhookResponseExcerpt {
+		delivery.ResponseBody = delivery.ResponseBody[:webhookResponseExcerpt]
+	}
+
+	if err := s.webhookDeliveryRepository.Create(delivery); err != nil {
+		s.logger.Error("webhookDeliveryRepository.Create", zap.Uint("tenant_id", delivery.TenantID),
+			zap.String("event_id", delivery.EventID), zap.Error(err))
+	}
+}
+
+func (s *webhookDeliveryService) GetDeliveries(tenantID uint, filter dto.WebhookDeliveryFilter) ([]model.WebhookDelivery, error) {
+	return s.webhookDeliveryRepository.FindByTenant(tenantID, filter)
+}
+
+func (s *webhookDeliveryService) GetTenantDeliveries(tenantID string, filter dto.WebhookDeliveryFilter) ([]model.WebhookDelivery, error) {
+	tid, err := helper.ParseTenantID(tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetDeliveries(tid, filter)
+}
+
+// maskSecret keeps the last four characters so a key can still be told apart.
+func maskSecret(secret string) string {
+	if len(secret) <= 4 {
+		return "****"
+	}
+	return "****" + secret[len(secret)-4:]
+}
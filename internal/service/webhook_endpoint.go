@@ -0,0 +1,155 @@
+package service
+
+import (
+	"fmt"
+	"payment-broker/internal/helper"
+	model "payment-broker/internal/model/db"
+	"payment-broker/internal/model/dto"
+	"payment-broker/internal/repository"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+type WebhookEndpointService interface {
+	Register(tenantID string, request *dto.WebhookEndpointRequest) (*model.WebhookEndpoint, error)
+	GetTenantEndpoints(tenantID string) ([]model.WebhookEndpoint, error)
+	UpdateTenantEndpoint(tenantID string, id uint, request *dto.WebhookEndpointRequest) (*model.WebhookEndpoint, error)
+	DeleteTenantEndpoint(tenantID string, id uint) error
+	Subscribers(tenantID uint, eventType string) ([]model.WebhookEndpoint, error)
+}
+
+type webhookEndpointService struct {
+	logger                    *zap.Logger
+	tenantRepository          repository.TenantRepository
+	webhookEndpointRepository repository.WebhookEndpointRepository
+}
+
+func NewWebhookEndpointService(logger *zap.Logger, tenantRepository repository.TenantRepository,
+	webhookEndpointRepository repository.WebhookEndpointRepository) WebhookEndpointService {
+	return &webhookEndpointService{
+		logger:                    logger,
+		tenantRepository:          tenantRepository,
+		webhookEndpointRepository: webhookEndpointRepository,
+	}
+}
+
+// Register adds an endpoint, active, unordered and receiving raw payloads
+// unless the request says otherwise.
+func (s *webhookEndpointService) Register(tenantID string, request *dto.WebhookEndpointRequest) (*model.WebhookEndpoint, error) {
+	tid, err := helper.ParseTenantID(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := &model.WebhookEndpoint{
+		TenantID:   tid,
+		URL:        request.URL,
+		EventTypes: strings.Join(request.EventTypes, ","),
+		Format:     model.WebhookFormatRaw,
+		Ordered:    request.Ordered != nil && *request.Ordered,
+		Active:     request.Active == nil || *request.Active,
+	}
+	if request.Format != "" {
+		endpoint.Format = request.Format
+	}
+	if err := s.webhookEndpointRepository.Create(endpoint); err != nil {
+		s.logger.Error("webhookEndpointRepository.Create", zap.Uint("tenant_id", tid), zap.Error(err))
+		return nil, fmt.Errorf("failed to register webhook endpoint: %w", err)
+	}
+
+	return endpoint, nil
+}
+
+func (s *webhookEndpointService) GetTenantEndpoints(tenantID string) ([]model.WebhookEndpoint, error) {
+	tid, err := helper.ParseTenantID(tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return s.webhookEndpointRepository.FindByTenant(tid)
+}
+
+// UpdateTenantEndpoint replaces the endpoint's URL and event types, and keeps
+// its format, ordering and whether it is active as they were when the request
+// doesn't say.
+func (s *webhookEndpointService) UpdateTenantEndpoint(tenantID string, id uint, request *dto.WebhookEndpointRequest) (*model.WebhookEndpoint, error) {
+	tid, err := helper.ParseTenantID(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, err := s.webhookEndpointRepository.FindForTenant(tid, id)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint.URL = request.URL
+	endpoint.EventTypes = strings.Join(request.EventTypes, ",")
+	if request.Format != "" {
+		endpoint.Format = request.Format
+	}
+	if request.Ordered != nil {
+		endpoint.Ordered = *request.Ordered
+	}
+	if request.Active != nil {
+		endpoint.Active = *request.Active
+	}
+
+	if err := s.webhookEndpointRepository.Save(endpoint); err != nil {
+		s.logger.Error("webhookEndpointRepository.Save", zap.Uint("tenant_id", tid), zap.Uint("id", id), zap.Error(err))
+		return nil, fmt.Errorf("failed to update webhook endpoint: %w", err)
+	}
+
+	return endpoint, nil
+}
+
+func (s *webhookEndpointService) DeleteTenantEndpoint(tenantID string, id uint) error {
+	tid, err := helper.ParseTenantID(tenantID)
+	if err != nil {
+		return err
+	}
+	return s.webhookEndpointRepository.Delete(tid, id)
+}
+
+// Subscribers returns the tenant's active endpoints subscribed to eventType.
+// A tenant that hasn't registered any endpoint still receives every event at
+// its WebhookURL, returned as an endpoint without an ID.
+func (s *webhookEndpointService) Subscribers(tenantID uint, eventType string) ([]model.WebhookEndpoint, error) {
+	endpoints, err := s.webhookEndpointRepository.FindByTenant(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(endpoints) == 0 {
+		webhookURL, err := s.tenantRepository.CheckTenant(tenantID)
+		if err != nil {
+			return nil, err
+		}
+		if webhookURL == nil || *webhookURL == "" {
+			return nil, fmt.Errorf("tenant not registered yet")
+		}
+		return []model.WebhookEndpoint{{TenantID: tenantID, URL: *webhookURL, EventTypes: "*", Format: model.WebhookFormatRaw, Active: true}}, nil
+	}
+
+	var subscribers []model.WebhookEndpoint
+	for _, endpoint := range endpoints {
+		if endpoint.Active && matchesEventType(strings.Split(endpoint.EventTypes, ","), eventType) {
+			subscribers = append(subscribers, endpoint)
+		}
+	}
+	return subscribers, nil
+}
+
+// matchesEventType accepts an exact event type, a prefix pattern such as
+// invoice.* for every invoice event, or * for everything.
+func matchesEventType(patterns []string, eventType string) bool {
+	for _, pattern := range patterns {
+		switch {
+		case pattern == "*", pattern == eventType:
+			return true
+		case strings.HasSuffix(pattern, ".*") && strings.HasPrefix(eventType, strings.TrimSuffix(pattern, "*")):
+			return true
+		}
+	}
+	return false
+}
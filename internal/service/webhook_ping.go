@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	model "payment-broker/internal/model/db"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type WebhookPingService interface {
+	SendTestEvent(ctx context.Context, tenantID uint, eventType string) ([]model.WebhookDelivery, error)
+}
+
+type webhookPingService struct {
+	logger        *zap.Logger
+	xenditService XenditService
+}
+
+func NewWebhookPingService(logger *zap.Logger, xenditService XenditService) WebhookPingService {
+	return &webhookPingService{
+		logger:        logger,
+		xenditService: xenditService,
+	}
+}
+
+// SendTestEvent delivers a synthetic event of eventType to the tenant's
+// subscribed endpoints the same way a Xendit callback is, without touching
+// the broker's own records. Resource IDs start with test_ so tenants can tell
+// the event apart. A failed delivery is reported in the returned attempts,
+// not as an error.
+func (s *webhookPingService) SendTestEvent(ctx context.Context, tenantID uint, eventType string) ([]model.WebhookDelivery, error) {
+	body, err := sampleEventBody(tenantID, eventType, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.xenditService.DeliverEvent(ctx, NewEvent(tenantID, body), "")
+	if len(deliveries) == 0 {
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("no webhook endpoint is subscribed to %s", eventType)
+	}
+
+	s.logger.Info("Test webhook sent", zap.Uint("tenant_id", tenantID), zap.String("event_type", eventType), zap.Error(err))
+	return deliveries, nil
+}
+
+// sampleEventBody builds a payload shaped like Xendit's for eventType, with
+// references carrying the tenant prefix as real callbacks do.
+func sampleEventBody(tenantID uint, eventType string, now time.Time) ([]byte, error) {
+	suffix := now.Format("20060102150405")
+	reference := fmt.Sprintf("%d:test_%s", tenantID, suffix)
+	created := now.Format(time.RFC3339)
+
+	var body map[string]interface{}
+	switch eventType {
+	case "invoice.paid":
+		// Invoice callbacks are the legacy shape without an event.
+		body = map[string]interface{}{
+			"id":              "test_inv_" + suffix,
+			"external_id":     reference,
+			"status":          "PAID",
+			"amount":          100000,
+			"paid_amount":     100000,
+			"currency":        "IDR",
+			"payment_method":  "BANK_TRANSFER",
+			"payment_channel": "BCA",
+			"paid_at":         created,
+			"updated":         created,
+		}
+	case "payout.succeeded", "payout.failed":
+		status := "SUCCEEDED"
+		if eventType == "payout.failed" {
+			status = "FAILED"
+		}
+		body = eventBody(eventType, created, map[string]interface{}{
+			"id":           "test_disb_" + suffix,
+			"reference_id": reference,
+			"channel_code": "ID_BCA",
+			"amount":       50000,
+			"currency":     "IDR",
+			"status":       status,
+		})
+	case "recurring.cycle.succeeded":
+		body = eventBody(eventType, created, map[string]interface{}{
+			"id":           "test_cycle_" + suffix,
+			"plan_id":      "test_plan_" + suffix,
+			"reference_id": reference,
+			"amount":       25000,
+			"currency":     "IDR",
+			"status":       "SUCCEEDED",
+		})
+	case "refund.succeeded":
+		body = eventBody(eventType, created, map[string]interface{}{
+			"id":           "test_rfd_" + suffix,
+			"invoice_id":   "test_inv_" + suffix,
+			"reference_id": reference,
+			"amount":       10000,
+			"currency":     "IDR",
+			"status":       "SUCCEEDED",
+		})
+	default:
+		return nil, fmt.Errorf("no sample event for %s", eventType)
+	}
+
+	return json.Marshal(body)
+}
+
+func eventBody(event, created string, data map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"event":   event,
+		"created": created,
+		"data":    data,
+	}
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"os"
+	model "payment-broker/internal/model/db"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultWebhookProbeInterval = 15 * time.Second
+
+	// webhookWorkerConcurrency caps how many breakers and ordered resources
+	// are sent to at the same time.
+	webhookWorkerConcurrency = 16
+)
+
+type WebhookWorkerService interface {
+	StartWorker(ctx context.Context)
+	Drain(ctx context.Context)
+}
+
+type webhookWorkerService struct {
+	logger                *zap.Logger
+	slots                 chan struct{}
+	xenditService         XenditService
+	webhookBreakerService WebhookBreakerService
+}
+
+func NewWebhookWorkerService(logger *zap.Logger, xenditService XenditService, webhookBreakerService WebhookBreakerService) WebhookWorkerService {
+	return &webhookWorkerService{
+		logger:                logger,
+		slots:                 make(chan struct{}, webhookWorkerConcurrency),
+		xenditService:         xenditService,
+		webhookBreakerService: webhookBreakerService,
+	}
+}
+
+// StartWorker drains due breakers and ordered deliveries every
+// WEBHOOK_PROBE_INTERVAL (15s by default), and as soon as an ordered delivery
+// is queued, until ctx is done.
+func (s *webhookWorkerService) StartWorker(ctx context.Context) {
+	interval, err := time.ParseDuration(os.Getenv("WEBHOOK_PROBE_INTERVAL"))
+	if err != nil || interval <= 0 {
+		interval = defaultWebhookProbeInterval
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.Drain(ctx)
+			case <-s.webhookBreakerService.Wake():
+				s.Drain(ctx)
+			}
+		}
+	}()
+}
+
+// Drain starts sending, in the background, the queue of every breaker due for
+// a probe and of every tenant resource with an ordered delivery ready. Each
+// queue goes out one delivery at a time while different queues run in
+// parallel. Queues beyond the concurrency cap wait for the next run.
+func (s *webhookWorkerService) Drain(ctx context.Context) {
+	breakers, err := s.webhookBreakerService.Due()
+	if err != nil {
+		s.logger.Error("webhookBreakerService.Due", zap.Error(err))
+	}
+
+	for i := range breakers {
+		breaker := &breakers[i]
+		if !s.spawn(func() { s.claimBreaker(ctx, breaker) }) {
+			return
+		}
+	}
+
+	heads, err := s.webhookBreakerService.OrderedHeads()
+	if err != nil {
+		s.logger.Error("webhookBreakerService.OrderedHeads", zap.Error(err))
+		return
+	}
+
+	for i := range heads {
+		head := &heads[i]
+		if !s.spawn(func() { s.drainOrdered(ctx, head) }) {
+			return
+		}
+	}
+}
+
+// spawn runs fn in the background if a slot is free.
+func (s *webhookWorkerService) spawn(fn func()) bool {
+	select {
+	case s.slots <- struct{}{}:
+	default:
+		return false
+	}
+
+	go func() {
+		defer func() { <-s.slots }()
+		fn()
+	}()
+	return true
+}
+
+func (s *webhookWorkerService) claimBreaker(ctx context.Context, breaker *model.WebhookBreaker) {
+	claimed, err := s.webhookBreakerService.Claim(breaker)
+	if err != nil {
+		s.logger.Error("webhookBreakerService.Claim", zap.Uint("breaker_id", breaker.ID), zap.Error(err))
+		return
+	}
+	if claimed {
+		s.drainBreaker(ctx, breaker)
+	}
+}
+
+func (s *webhookWorkerService) drainBreaker(ctx context.Context, breaker *model.WebhookBreaker) {
+	probed := false
+	for ctx.Err() == nil {
+		item, event, err := s.webhookBreakerService.Next(breaker)
+		if err != nil {
+			s.reopen(breaker, err)
+			return
+		}
+		if item == nil {
+			if err := s.webhookBreakerService.Close(breaker, probed); err != nil {
+				s.logger.Error("webhookBreakerService.Close", zap.Uint("breaker_id", breaker.ID), zap.Error(err))
+			}
+			return
+		}
+
+		endpoint := model.WebhookEndpoint{ID: item.EndpointID, TenantID: item.TenantID, URL: item.URL, Format: item.Format}
+		if _, err := s.xenditService.SendWebhook(ctx, *event, endpoint, item.APIKey); err != nil {
+			s.reopen(breaker, err)
+			return
+		}
+		probed = true
+
+		if err := s.webhookBreakerService.Delivered(breaker, item); err != nil {
+			s.reopen(breaker, err)
+			return
+		}
+	}
+
+	// Stopped mid-queue: leave the breaker for the next run to claim again.
+	s.reopen(breaker, ctx.Err())
+}
+
+func (s *webhookWorkerService) reopen(breaker *model.WebhookBreaker, cause error) {
+	if err := s.webhookBreakerService.Reopen(breaker, cause); err != nil {
+		s.logger.Error("webhookBreakerService.Reopen", zap.Uint("breaker_id", breaker.ID), zap.Error(err))
+	}
+}
+
+// drainOrdered sends the deliveries queued for one tenant resource in order.
+// A failure keeps the delivery at the head with a backoff, and an open
+// breaker leaves it until the claim runs out, so later deliveries of the
+// resource never overtake it.
+func (s *webhookWorkerService) drainOrdered(ctx context.Context, item *model.QueuedWebhook) {
+	for item != nil && ctx.Err() == nil {
+		claimed, err := s.webhookBreakerService.ClaimOrdered(item)
+		if err != nil {
+			s.logger.Error("webhookBreakerService.ClaimOrdered", zap.Uint("id", item.ID), zap.Error(err))
+			return
+		}
+		if !claimed {
+			return
+		}
+
+		if breaker, err := s.webhookBreakerService.Check(item.TenantID, item.URL); err != nil || breaker != nil {
+			return
+		}
+
+		event, err := s.webhookBreakerService.Decode(item)
+		if err != nil {
+			// An event that can't be read would hold the resource forever.
+			s.logger.Error("Dropping unreadable ordered webhook", zap.Uint("id", item.ID), zap.Error(err))
+		} else {
+			endpoint := model.WebhookEndpoint{ID: item.EndpointID, TenantID: item.TenantID, URL: item.URL, Format: item.Format}
+			_, err := s.xenditService.SendWebhook(ctx, *event, endpoint, item.APIKey)
+			s.webhookBreakerService.Report(item.TenantID, item.URL, err)
+			if err != nil {
+				if err := s.webhookBreakerService.RetryOrdered(item, err); err != nil {
+					s.logger.Error("webhookBreakerService.RetryOrdered", zap.Uint("id", item.ID), zap.Error(err))
+				}
+				return
+			}
+		}
+
+		if err := s.webhookBreakerService.Delivered(nil, item); err != nil {
+			s.logger.Error("webhookBreakerService.Delivered", zap.Uint("id", item.ID), zap.Error(err))
+			return
+		}
+
+		item, err = s.webhookBreakerService.NextOrdered(item)
+		if err != nil {
+			s.logger.Error("webhookBreakerService.NextOrdered", zap.Error(err))
+			return
+		}
+	}
+}
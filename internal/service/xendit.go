@@ -2,46 +2,166 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
+	model "payment-broker/internal/model/db"
 	"payment-broker/internal/model/dto"
 	"payment-broker/internal/repository"
-	"strconv"
+	"sync"
+	"time"
 
 	"github.com/go-resty/resty/v2"
 	"go.uber.org/zap"
 )
 
 type XenditService interface {
-	CreateRequest(ctx context.Context, body interface{}, url string, accountID string) (*dto.XenditResponse, error)
-	ProxyWebhook(ctx context.Context, tenantID string, body interface{}, api_key string) error
+	CreateAccount(ctx context.Context, body dto.XenditCreateAccount) (*dto.XenditAccount, error)
+	CreateSplitRule(ctx context.Context, body dto.XenditCreateSplitRule) (*dto.XenditSplitRule, error)
+	CreateRequest(ctx context.Context, body interface{}, url string, accountID string, splitRuleID string) (*dto.XenditResponse, error)
+	GetRequest(ctx context.Context, url string, query url.Values, accountID string) (*dto.XenditResponse, error)
+	UpdateRequest(ctx context.Context, body interface{}, url string, accountID string) (*dto.XenditResponse, error)
+	ProxyWebhook(ctx context.Context, event dto.Event, api_key string) error
+	DeliverEvent(ctx context.Context, event dto.Event, api_key string) ([]model.WebhookDelivery, error)
+	SendWebhook(ctx context.Context, event dto.Event, endpoint model.WebhookEndpoint, api_key string) (*model.WebhookDelivery, error)
 }
 
 type xenditService struct {
-	resty            *resty.Client
-	logger           *zap.Logger
-	splitRuleID      string
-	tenantRepository repository.TenantRepository
-	baseURL          string
+	resty                  *resty.Client
+	webhookResty           *resty.Client
+	logger                 *zap.Logger
+	tenantRepository       repository.TenantRepository
+	webhookEndpointService WebhookEndpointService
+	webhookDeliveryService WebhookDeliveryService
+	webhookBreakerService  WebhookBreakerService
+	baseURL                string
 }
 
-func NewXenditService(resty *resty.Client, logger *zap.Logger) XenditService {
+// NewXenditService calls Xendit with resty and tenant webhooks with
+// webhookResty, which must enforce the egress policy.
+func NewXenditService(resty *resty.Client, webhookResty *resty.Client, logger *zap.Logger, tenantRepository repository.TenantRepository,
+	webhookEndpointService WebhookEndpointService, webhookDeliveryService WebhookDeliveryService,
+	webhookBreakerService WebhookBreakerService) XenditService {
 	return &xenditService{
-		resty:       resty,
-		logger:      logger,
-		splitRuleID: os.Getenv("XENDIT_SPLIT_RULE_ID"),
-		baseURL:     os.Getenv("XENDIT_BASE_URL"),
+		resty:                  resty,
+		webhookResty:           webhookResty,
+		logger:                 logger,
+		tenantRepository:       tenantRepository,
+		webhookEndpointService: webhookEndpointService,
+		webhookDeliveryService: webhookDeliveryService,
+		webhookBreakerService:  webhookBreakerService,
+		baseURL:                os.Getenv("XENDIT_BASE_URL"),
 	}
 }
 
-func (s *xenditService) CreateRequest(ctx context.Context, body interface{}, url string, accountID string) (*dto.XenditResponse, error) {
+// CreateAccount registers a new xenPlatform sub-account under the master account,
+// so it is sent without the for-user-id and with-split-rule headers.
+func (s *xenditService) CreateAccount(ctx context.Context, body dto.XenditCreateAccount) (*dto.XenditAccount, error) {
 	resp, err := s.resty.R().
+		SetContext(ctx).
+		SetBasicAuth(os.Getenv("XENDIT_API_KEY"), "").
+		SetBody(body).
+		Post(s.baseURL + "/v2/accounts")
+
+	if err != nil {
+		s.logger.Error("failed to reach Xendit API /v2/accounts", zap.Error(err))
+		return nil, fmt.Errorf("failed to reach Xendit API /v2/accounts: %w", err)
+	}
+
+	if resp.IsError() {
+		s.logger.Error("Xendit API /v2/accounts", zap.Int("status_code", resp.StatusCode()), zap.ByteString("body", resp.Body()))
+		return nil, fmt.Errorf("xendit rejected account creation with status %d: %s", resp.StatusCode(), resp.String())
+	}
+
+	var account dto.XenditAccount
+	if err := json.Unmarshal(resp.Body(), &account); err != nil {
+		return nil, fmt.Errorf("failed to parse Xendit account response: %w", err)
+	}
+
+	return &account, nil
+}
+
+// CreateSplitRule creates a split rule owned by the master account, which can then
+// be applied to sub-account requests through the with-split-rule header.
+func (s *xenditService) CreateSplitRule(ctx context.Context, body dto.XenditCreateSplitRule) (*dto.XenditSplitRule, error) {
+	resp, err := s.resty.R().
+		SetContext(ctx).
+		SetBasicAuth(os.Getenv("XENDIT_API_KEY"), "").
+		SetBody(body).
+		Post(s.baseURL + "/split_rules")
+
+	if err != nil {
+		s.logger.Error("failed to reach Xendit API /split_rules", zap.Error(err))
+		return nil, fmt.Errorf("failed to reach Xendit API /split_rules: %w", err)
+	}
+
+	if resp.IsError() {
+		s.logger.Error("Xendit API /split_rules", zap.Int("status_code", resp.StatusCode()), zap.ByteString("body", resp.Body()))
+		return nil, fmt.Errorf("xendit rejected split rule with status %d: %s", resp.StatusCode(), resp.String())
+	}
+
+	var rule dto.XenditSplitRule
+	if err := json.Unmarshal(resp.Body(), &rule); err != nil {
+		return nil, fmt.Errorf("failed to parse Xendit split rule response: %w", err)
+	}
+
+	return &rule, nil
+}
+
+func (s *xenditService) CreateRequest(ctx context.Context, body interface{}, url string, accountID string, splitRuleID string) (*dto.XenditResponse, error) {
+	req := s.resty.R().
+		SetBasicAuth(os.Getenv("XENDIT_API_KEY"), "").
+		SetHeader("for-user-id", accountID).
+		SetBody(body)
+
+	if splitRuleID != "" {
+		req.SetHeader("with-split-rule", splitRuleID)
+	}
+
+	resp, err := req.Post(s.baseURL + url)
+
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("failed to reach Xendit API %s", url), zap.Error(err))
+		return nil, fmt.Errorf("failed to reach Xendit API %s: %w", url, err)
+	}
+
+	return &dto.XenditResponse{
+		StatusCode: resp.StatusCode(),
+		Header:     resp.Header(),
+		Body:       resp.Body(),
+	}, nil
+}
+
+func (s *xenditService) GetRequest(ctx context.Context, url string, query url.Values, accountID string) (*dto.XenditResponse, error) {
+	resp, err := s.resty.R().
+		SetContext(ctx).
+		SetBasicAuth(os.Getenv("XENDIT_API_KEY"), "").
+		SetHeader("for-user-id", accountID).
+		SetQueryParamsFromValues(query).
+		Get(s.baseURL + url)
+
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("failed to reach Xendit API %s", url), zap.Error(err))
+		return nil, fmt.Errorf("failed to reach Xendit API %s: %w", url, err)
+	}
+
+	return &dto.XenditResponse{
+		StatusCode: resp.StatusCode(),
+		Header:     resp.Header(),
+		Body:       resp.Body(),
+	}, nil
+}
+
+func (s *xenditService) UpdateRequest(ctx context.Context, body interface{}, url string, accountID string) (*dto.XenditResponse, error) {
+	resp, err := s.resty.R().
+		SetContext(ctx).
 		SetBasicAuth(os.Getenv("XENDIT_API_KEY"), "").
 		SetHeader("for-user-id", accountID).
-		SetHeader("with-split-rule", s.splitRuleID).
 		SetBody(body).
-		Post(s.baseURL + url)
+		Patch(s.baseURL + url)
 
 	if err != nil {
 		s.logger.Error(fmt.Sprintf("failed to reach Xendit API %s", url), zap.Error(err))
@@ -55,38 +175,153 @@ func (s *xenditService) CreateRequest(ctx context.Context, body interface{}, url
 	}, nil
 }
 
-func (s *xenditService) ProxyWebhook(ctx context.Context, tenantID string, body interface{}, api_key string) error {
-	id, err := strconv.ParseUint(tenantID, 10, 32)
+// ProxyWebhook delivers the event to every tenant endpoint subscribed to its
+// type. An endpoint that fails has the delivery queued on its breaker for the
+// worker to retry, so Xendit can be acknowledged without the endpoints that
+// accepted the event receiving it again. An error is only returned when a
+// failed delivery couldn't be queued.
+func (s *xenditService) ProxyWebhook(ctx context.Context, event dto.Event, api_key string) error {
+	_, err := s.deliver(ctx, event, api_key, true)
+	return err
+}
+
+// DeliverEvent sends the event to the tenant's subscribed endpoints at the same
+// time, as Xendit's payload or in the envelope depending on each endpoint's
+// format, and returns the recorded attempts. An endpoint whose breaker is open
+// gets the event queued for the breaker worker instead, which counts as
+// delivered here and shows in the result as an unsaved attempt with an error.
+// Failed attempts are returned as errors and not retried.
+func (s *xenditService) DeliverEvent(ctx context.Context, event dto.Event, api_key string) ([]model.WebhookDelivery, error) {
+	return s.deliver(ctx, event, api_key, false)
+}
+
+func (s *xenditService) deliver(ctx context.Context, event dto.Event, api_key string, retry bool) ([]model.WebhookDelivery, error) {
+	endpoints, err := s.webhookEndpointService.Subscribers(event.TenantID, event.Type)
 	if err != nil {
-		return fmt.Errorf("invalid tenant ID format: %w", err)
+		s.logger.Error("webhookEndpointService.Subscribers", zap.Uint("tenantID", event.TenantID), zap.Error(err))
+		return nil, fmt.Errorf("failed to check tenant: %w", err)
+	}
+
+	if len(endpoints) == 0 {
+		s.logger.Debug("No webhook endpoint subscribed", zap.Uint("tenantID", event.TenantID), zap.String("event_type", event.Type))
+		return nil, nil
 	}
 
-	webhook_url, err := s.tenantRepository.CheckTenant(uint(id))
+	deliveries := make([]model.WebhookDelivery, len(endpoints))
+	errs := make([]error, len(endpoints))
+	var wg sync.WaitGroup
+	for i, endpoint := range endpoints {
+		wg.Add(1)
+		go func(i int, endpoint model.WebhookEndpoint) {
+			defer wg.Done()
+			errs[i] = s.deliverToEndpoint(event, endpoint, api_key, retry, &deliveries[i])
+		}(i, endpoint)
+	}
+	wg.Wait()
+
+	return deliveries, errors.Join(errs...)
+}
 
+func (s *xenditService) deliverToEndpoint(event dto.Event, endpoint model.WebhookEndpoint, api_key string, retry bool, delivery *model.WebhookDelivery) error {
+	queued := func(reason string) {
+		*delivery = model.WebhookDelivery{
+			TenantID:   event.TenantID,
+			EndpointID: endpoint.ID,
+			EventID:    event.ID,
+			EventType:  event.Type,
+			URL:        endpoint.URL,
+			Error:      reason,
+		}
+	}
+
+	// Ordered endpoints always go through the worker, which also respects
+	// the breaker, so the queue alone decides the order.
+	if endpoint.Ordered {
+		queued("queued for ordered delivery")
+		return s.webhookBreakerService.EnqueueOrdered(event, endpoint, api_key)
+	}
+
+	breaker, err := s.webhookBreakerService.Check(event.TenantID, endpoint.URL)
 	if err != nil {
-		s.logger.Error("tenantRepository.CheckTenant", zap.String("tenantID", tenantID), zap.Error(err))
-		return fmt.Errorf("failed to check tenant: %w", err)
+		// Deliver anyway rather than hold events back on a database error.
+		s.logger.Error("webhookBreakerService.Check", zap.Uint("tenantID", event.TenantID), zap.Error(err))
 	}
 
-	if webhook_url == nil {
-		return fmt.Errorf("tenant not registered yet")
+	if breaker != nil {
+		queued(fmt.Sprintf("circuit breaker is %s, delivery queued", breaker.State))
+		return s.webhookBreakerService.Enqueue(breaker, event, endpoint, api_key)
 	}
 
-	resp, err := s.resty.R().
-		SetHeader("Content-Type", "application/json").
-		SetHeader("X-Api-Key", api_key).
+	err = s.postWebhook(event, endpoint, api_key, delivery)
+	if err == nil || !retry {
+		s.webhookBreakerService.Report(event.TenantID, endpoint.URL, err)
+		return err
+	}
+
+	if queueErr := s.webhookBreakerService.Retry(event, endpoint, api_key, err); queueErr != nil {
+		s.logger.Error("webhookBreakerService.Retry", zap.Uint("tenantID", event.TenantID), zap.String("url", endpoint.URL), zap.Error(queueErr))
+		return errors.Join(err, queueErr)
+	}
+	return nil
+}
+
+// SendWebhook sends the event to one endpoint regardless of its breaker, for
+// the breaker worker to probe and drain the queue with.
+func (s *xenditService) SendWebhook(ctx context.Context, event dto.Event, endpoint model.WebhookEndpoint, api_key string) (*model.WebhookDelivery, error) {
+	var delivery model.WebhookDelivery
+	err := s.postWebhook(event, endpoint, api_key, &delivery)
+	return &delivery, err
+}
+
+// postWebhook sends the event in the endpoint's format and records the attempt.
+func (s *xenditService) postWebhook(event dto.Event, endpoint model.WebhookEndpoint, api_key string, delivery *model.WebhookDelivery) error {
+	webhook_url := endpoint.URL
+	body := []byte(event.Data)
+	if endpoint.Format == model.WebhookFormatEnvelopeV1 {
+		envelope, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to encode event %s: %w", event.ID, err)
+		}
+		body = envelope
+	}
+
+	headers := map[string]string{
+		"Content-Type": "application/json",
+		"X-Api-Key":    api_key,
+	}
+	*delivery = model.WebhookDelivery{
+		TenantID:       event.TenantID,
+		EndpointID:     endpoint.ID,
+		EventID:        event.ID,
+		EventType:      event.Type,
+		URL:            webhook_url,
+		RequestHeaders: headers,
+		RequestBody:    string(body),
+	}
+	defer s.webhookDeliveryService.Record(delivery)
+
+	start := time.Now()
+	resp, err := s.webhookResty.R().
+		SetHeaders(headers).
 		SetBody(body).
-		Post(*webhook_url)
+		Post(webhook_url)
+	delivery.LatencyMs = time.Since(start).Milliseconds()
 
 	if err != nil {
-		s.logger.Error("resty.Post", zap.String("webhook_url", *webhook_url), zap.Error(err))
-		return fmt.Errorf("failed to post to webhook: %w", err)
+		s.logger.Error("resty.Post", zap.String("webhook_url", webhook_url), zap.Error(err))
+		delivery.Error = err.Error()
+		return fmt.Errorf("failed to post to webhook %s: %w", webhook_url, err)
 	}
 
+	delivery.ResponseStatus = resp.StatusCode()
+	delivery.ResponseBody = string(resp.Body())
+
 	if resp.StatusCode() != http.StatusOK {
-		s.logger.Error("resty.Post", zap.String("webhook_url", *webhook_url), zap.Int("status_code", resp.StatusCode()))
-		return fmt.Errorf("failed to post to webhook: %w", err)
+		s.logger.Error("resty.Post", zap.String("webhook_url", webhook_url), zap.Int("status_code", resp.StatusCode()))
+		delivery.Error = fmt.Sprintf("endpoint responded with status %d", resp.StatusCode())
+		return fmt.Errorf("failed to post to webhook %s: status %d", webhook_url, resp.StatusCode())
 	}
 
+	delivery.Succeeded = true
 	return nil
 }